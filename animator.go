@@ -2,6 +2,8 @@
 package animations
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/AllenDang/giu"
@@ -14,6 +16,8 @@ const (
 	// DefaultDuration is animation's duration set by default.
 	// You can change this by (*Animator).Durations().
 	DefaultDuration = time.Second / 4
+	// RepeatForever could be passed to (*AnimatorWidget).Repeat to repeat animation until it is stopped.
+	RepeatForever = -1
 )
 
 var (
@@ -35,8 +39,31 @@ type AnimatorWidget struct {
 	// playback properties
 	duration time.Duration
 	fps      int
+	speed    float64
 
 	easingAlgorithm EasingAlgorithmType
+	easingFunc      EasingAlgorithm
+
+	clock        Clock
+	driver       Driver
+	interruption InterruptionPolicy
+
+	clampKeyFrames bool
+	keyFramePath   KeyFramePath
+
+	motionReduction         MotionReduction
+	overrideMotionReduction bool
+
+	delay       time.Duration
+	cycleDelay  time.Duration
+	repeat      int
+	repeatDelay time.Duration
+
+	// lifecycle callbacks
+	onStart           func()
+	onKeyFrameReached func(kf KeyFrame)
+	onFinish          func()
+	onCancel          func()
 
 	// triggers
 	triggerType    TriggerType
@@ -45,6 +72,10 @@ type AnimatorWidget struct {
 
 	animation    Animation
 	numKeyFrames KeyFrame // <- Filled in in Animator call. SHOULD NOT CHANGE after it.
+
+	// state is set for animators returned by Animators. They use it instead of giu state,
+	// so that they don't keep the state alive when the animator stops being built.
+	state *animatorState
 }
 
 // Animator creates animation new AnimatorWidget.
@@ -54,7 +85,9 @@ func Animator(a Animation) *AnimatorWidget {
 		animation:       a,
 		duration:        DefaultDuration,
 		fps:             DefaultFPS,
+		speed:           1,
 		easingAlgorithm: EasingAlgNone,
+		clock:           systemClock{},
 		numKeyFrames:    a.KeyFramesCount(),
 	}
 
@@ -65,14 +98,15 @@ func Animator(a Animation) *AnimatorWidget {
 // It may be really important when using TransitionAnimation, because
 // sometimes when using sub-animators inside of Transition, it may happen
 // that the second AnimatorWidget will receive the same ID as the previous one.
-// It may cause unexpected behaviors.
+// It may cause unexpected behaviors (see SetDuplicateIDHandler).
 func (a *AnimatorWidget) ID(newID giu.ID) *AnimatorWidget {
 	a.id = newID
 
 	return a
 }
 
-// FPS allows to specify FPS value.
+// FPS allows to specify how many times per second a running animation requests a redraw (see DriverTicker).
+// It does not affect the animation's pace - progress is measured with animator's Clock.
 // CAUTION: it will take effect after next call to Start - not applied to currently plaid animation.
 func (a *AnimatorWidget) FPS(fps int) *AnimatorWidget {
 	a.fps = fps
@@ -80,7 +114,8 @@ func (a *AnimatorWidget) FPS(fps int) *AnimatorWidget {
 	return a
 }
 
-// Duration allows to specify duration value.
+// Duration allows to specify duration value (of a single key frame).
+// It may be overridden for particular key frames (see KeyFrameTiming).
 // CAUTION: it will take effect after next call to Start - not applied to currently plaid animation.
 func (a *AnimatorWidget) Duration(duration time.Duration) *AnimatorWidget {
 	a.duration = duration
@@ -88,13 +123,125 @@ func (a *AnimatorWidget) Duration(duration time.Duration) *AnimatorWidget {
 	return a
 }
 
+// Speed sets speed of the animation (e.g. 2 plays it twice as fast).
+// It multiplies with the global time scale (see TimeScale).
+// Default is 1. Negative values are treated as 0.
+func (a *AnimatorWidget) Speed(factor float64) *AnimatorWidget {
+	a.speed = factor
+
+	return a
+}
+
+// timeScale returns speed of animator's time including global time scale.
+func (a *AnimatorWidget) timeScale() float64 {
+	return max(a.speed, 0) * globalTimeScale()
+}
+
+// ClampKeyFrames makes key frames not wrap around: playing forward stops on the last key frame
+// and playing backward stops on the first one (so e.g. Start(PlayForward) on the last key frame does nothing).
+// See also CanAdvance.
+func (a *AnimatorWidget) ClampKeyFrames() *AnimatorWidget {
+	a.clampKeyFrames = true
+
+	return a
+}
+
+// KeyFramePath sets how StartKeyFrames gets from the beginning to the destination key frame (see KeyFramePath).
+func (a *AnimatorWidget) KeyFramePath(path KeyFramePath) *AnimatorWidget {
+	a.keyFramePath = path
+
+	return a
+}
+
+// MotionReduction overrides the way the animation is played when reduced motion
+// is enabled (see SetReducedMotion and MotionReducer).
+func (a *AnimatorWidget) MotionReduction(r MotionReduction) *AnimatorWidget {
+	a.motionReduction = r
+	a.overrideMotionReduction = true
+
+	return a
+}
+
+// Delay sets a delay between starting the animation (e.g. by Start) and the beginning of its progress.
+// While waiting, animation is built normally (Animation.BuildNormal) but IsRunning returns true.
+// Pause also applies to the delay.
+func (a *AnimatorWidget) Delay(d time.Duration) *AnimatorWidget {
+	a.delay = d
+
+	return a
+}
+
+// CycleDelay sets a delay before every next cycle of the animation (see StartCycle and StartKeyFrames).
+// It works like Delay.
+func (a *AnimatorWidget) CycleDelay(d time.Duration) *AnimatorWidget {
+	a.cycleDelay = d
+
+	return a
+}
+
+// Repeat sets how many times the animation should be repeated after it finishes.
+// Repeating means calling the same Start* method again, so e.g. Start(PlayForward)
+// will go on from the key frame it stopped.
+// Use RepeatForever to repeat the animation until it gets stopped (see Stop).
+func (a *AnimatorWidget) Repeat(count int) *AnimatorWidget {
+	a.repeat = count
+
+	return a
+}
+
+// RepeatDelay sets delay between repetitions of the animation (see Repeat).
+// It works like Delay.
+func (a *AnimatorWidget) RepeatDelay(d time.Duration) *AnimatorWidget {
+	a.repeatDelay = d
+
+	return a
+}
+
 // EasingAlgorithm allows to specify easing algorithm.
+// It may be overridden for particular key frames (see KeyFrameTiming).
+// CAUTION: it will take effect after next call to Start - not applied to currently plaid animation.
 func (a *AnimatorWidget) EasingAlgorithm(alg EasingAlgorithmType) *AnimatorWidget {
 	a.easingAlgorithm = alg
 
 	return a
 }
 
+// EasingFunc allows to specify a custom easing function.
+// If set, it is used instead of animator's EasingAlgorithm.
+// It may be overridden for particular key frames (see KeyFrameTiming).
+// To use your function as EasingAlgorithmType (e.g. in KeyFrameTiming), see RegisterEasing.
+// CAUTION: it will take effect after next call to Start - not applied to currently plaid animation.
+func (a *AnimatorWidget) EasingFunc(f EasingAlgorithm) *AnimatorWidget {
+	a.easingFunc = f
+
+	return a
+}
+
+// Clock allows to replace a source of time used by the animator.
+// By default system clock is used. See also FakeClock.
+// CAUTION: it will take effect after next call to Start - not applied to currently plaid animation.
+func (a *AnimatorWidget) Clock(c Clock) *AnimatorWidget {
+	a.clock = c
+
+	return a
+}
+
+// Driver allows to specify how the animation is advanced (see Driver).
+// CAUTION: it will take effect after next call to Start - not applied to currently plaid animation.
+func (a *AnimatorWidget) Driver(d Driver) *AnimatorWidget {
+	a.driver = d
+
+	return a
+}
+
+// Interruption allows to specify what happens if the animation is started
+// while it is running (see InterruptionPolicy).
+func (a *AnimatorWidget) Interruption(policy InterruptionPolicy) *AnimatorWidget {
+	a.interruption = policy
+
+	return a
+}
+
 // Trigger sets automatic triggering of animation.
 //
 //	Example: (*AnimatorWidget).Trigger(TriggerOnChange, imgui.IsItemHovered)
@@ -109,197 +256,891 @@ func (a *AnimatorWidget) Trigger(triggerType TriggerType, playMode PlayMode, f T
 // Start starts the animation.
 // It plays one single frame forwards/backwards (depending on playMode).
 func (a *AnimatorWidget) Start(playMode PlayMode) {
+	a.StartContext(context.Background(), playMode)
+}
+
+// StartContext works like Start, but the animation gets stopped (see Stop)
+// on its current key frame when ctx is cancelled.
+// If ctx is already cancelled, it does nothing.
+func (a *AnimatorWidget) StartContext(ctx context.Context, playMode PlayMode) {
+	isReversal := func(s *animatorState) bool {
+		from, to := s.currentKeyFrame, s.destinationKeyFrame
+		if s.isReversed {
+			from, to = to, from
+		}
+
+		return getWithDelta(to, a.numKeyFrames, playModeDelta(playMode)) == from
+	}
+
+	restart := func(animator *AnimatorWidget) { animator.StartContext(ctx, playMode) }
+	if a.interrupt(restart, isReversal) {
+		return
+	}
+
 	state := a.getState()
 	state.m.Lock()
 	cf := state.currentKeyFrame
 	state.m.Unlock()
 
-	var delta KeyFrame = 1
-
-	if playMode == PlayBackward {
-		delta = -1
-	}
-
-	destinationFrame := getWithDelta(cf, a.numKeyFrames, delta)
-	a.StartKeyFrames(cf, destinationFrame, 0, playMode)
+	destinationFrame := getWithDelta(cf, a.numKeyFrames, playModeDelta(playMode))
+	a.startKeyFrames(ctx, cf, destinationFrame, 0, playMode, KeyFramePathSequential, restart)
 }
 
 // StartKeyFrames initializes animation playback from beginKF to destination KF in direction
 // specified by playMode.
+// With PlayPingPong, animation is played from beginKF to destinationKF and then back to beginKF.
+// The way key frames are visited depends on animator's KeyFramePath.
+// cyclesCount must not be negative (use Repeat to play the animation forever).
 func (a *AnimatorWidget) StartKeyFrames(beginKF, destinationKF KeyFrame, cyclesCount int, playMode PlayMode) {
+	a.StartKeyFramesContext(context.Background(), beginKF, destinationKF, cyclesCount, playMode)
+}
+
+// StartKeyFramesContext works like StartKeyFrames, but the animation gets stopped (see Stop)
+// on its current key frame when ctx is cancelled.
+func (a *AnimatorWidget) StartKeyFramesContext(
+	ctx context.Context,
+	beginKF, destinationKF KeyFrame,
+	cyclesCount int,
+	playMode PlayMode,
+) {
+	if err := a.validateKeyFrames(beginKF, destinationKF); err != nil {
+		reportError(err)
+
+		return
+	}
+
+	if err := a.validateCycles(cyclesCount); err != nil {
+		reportError(err)
+
+		return
+	}
+
+	restart := func(animator *AnimatorWidget) {
+		animator.StartKeyFramesContext(ctx, beginKF, destinationKF, cyclesCount, playMode)
+	}
+
+	if a.keyFramePath == KeyFramePathShortest && playMode != PlayPingPong {
+		playMode = a.shortestPlayMode(beginKF, destinationKF, playMode)
+	}
+
+	if a.interrupt(restart, isOppositeTo(playMode)) {
+		return
+	}
+
+	a.startKeyFrames(ctx, beginKF, destinationKF, cyclesCount, playMode, a.keyFramePath, restart)
+}
+
+// shortestPlayMode returns direction in which the way from beginKF to destinationKF is shorter.
+// If key frames are clamped, the only direction that does not wrap is chosen.
+// If both ways are equal, forward is preferred. If there is no choice, playMode is returned.
+func (a *AnimatorWidget) shortestPlayMode(beginKF, destinationKF KeyFrame, playMode PlayMode) PlayMode {
+	switch {
+	case beginKF == destinationKF:
+		return playMode
+	case a.clampKeyFrames && destinationKF > beginKF:
+		return PlayForward
+	case a.clampKeyFrames:
+		return PlayBackward
+	}
+
+	n := int(a.numKeyFrames)
+	forward := (int(destinationKF) - int(beginKF) + n) % n
+
+	if forward*2 <= n {
+		return PlayForward
+	}
+
+	return PlayBackward
+}
+
+// StartCycle plays an animation from start to end (optionally from end to start).
+// numberOfCycles must not be negative (use Repeat to play the animation forever).
+func (a *AnimatorWidget) StartCycle(numberOfCycles int, playMode PlayMode) {
+	a.StartCycleContext(context.Background(), numberOfCycles, playMode)
+}
+
+// StartCycleContext works like StartCycle, but the animation gets stopped (see Stop)
+// on its current key frame when ctx is cancelled.
+func (a *AnimatorWidget) StartCycleContext(ctx context.Context, numberOfCycles int, playMode PlayMode) {
+	if err := a.validateCycles(numberOfCycles); err != nil {
+		reportError(err)
+
+		return
+	}
+
+	restart := func(animator *AnimatorWidget) { animator.StartCycleContext(ctx, numberOfCycles, playMode) }
+	if a.interrupt(restart, isOppositeTo(playMode)) {
+		return
+	}
+
+	state := a.getState()
+	state.m.Lock()
+	b := state.currentKeyFrame
+	state.m.Unlock()
+
+	a.startKeyFrames(ctx, b, b, numberOfCycles, playMode, KeyFramePathSequential, restart)
+}
+
+// startKeyFrames is an internal implementation of StartKeyFrames.
+// rerun is a Start* call that will be used to repeat the animation (see Repeat).
+// KeyFramePathShortest should be resolved by the caller (it is treated like KeyFramePathSequential here).
+func (a *AnimatorWidget) startKeyFrames(
+	ctx context.Context,
+	beginKF, destinationKF KeyFrame,
+	cyclesCount int,
+	playMode PlayMode,
+	path KeyFramePath,
+	rerun startCall,
+) {
+	if ctx.Err() != nil {
+		return
+	}
+
 	state := a.getState()
 
 	state.m.Lock()
 	state.currentKeyFrame = beginKF
 	state.longTimeDestinationKeyFrame = destinationKF
+	state.destinationKeyFrame = getWithDelta(beginKF, a.numKeyFrames, playModeDelta(playMode))
+	state.numberOfCycles = cyclesCount
 
-	switch playMode {
-	case PlayForward:
-		state.destinationKeyFrame = getWithDelta(beginKF, a.numKeyFrames, 1)
-	case PlayBackward:
-		state.destinationKeyFrame = getWithDelta(beginKF, a.numKeyFrames, -1)
+	state.isDirect = path == KeyFramePathDirect && beginKF != destinationKF
+	if state.isDirect {
+		state.destinationKeyFrame = destinationKF
+		state.numberOfCycles = 0
 	}
 
-	state.numberOfCycles = cyclesCount
+	state.isPingPong = playMode == PlayPingPong
+	state.pingPongOrigin = beginKF
+	state.pingPongCycles = cyclesCount
+
+	state.rerun = rerun
+	state.repeatsLeft = a.repeat
 
 	state.m.Unlock()
 
-	a.start(playMode)
+	a.start(ctx, playMode.direction())
 }
 
-// StartCycle plays an animation from start to end (optionally from end to start).
-func (a *AnimatorWidget) StartCycle(numberOfCycles int, playMode PlayMode) {
+// CanAdvance returns true if the animation can be played from the current key frame
+// in direction of mode (it is false e.g. on the last key frame if ClampKeyFrames is set).
+func (a *AnimatorWidget) CanAdvance(mode PlayMode) bool {
 	state := a.getState()
-	b := state.currentKeyFrame
-	a.StartKeyFrames(b, b, numberOfCycles, playMode)
+
+	state.m.Lock()
+	defer state.m.Unlock()
+
+	return a.canAdvanceFrom(state.currentKeyFrame, mode)
+}
+
+// canAdvanceFrom returns true if the animation can go to the next key frame from kf in direction of mode.
+func (a *AnimatorWidget) canAdvanceFrom(kf KeyFrame, mode PlayMode) bool {
+	if a.numKeyFrames < 2 {
+		return false
+	}
+
+	if !a.clampKeyFrames {
+		return true
+	}
+
+	if mode.direction() == PlayBackward {
+		return kf > 0
+	}
+
+	return kf < a.numKeyFrames-1
+}
+
+// Stop stops running animation. The animation stays on its current key frame.
+// It also drops queued Start* calls and repetitions.
+func (a *AnimatorWidget) Stop() {
+	state := a.getState()
+
+	state.m.Lock()
+	stop := state.stop
+	state.m.Unlock()
+
+	a.stopPlayback(state, stop)
+}
+
+// stopPlayback stops playback identified by stop (if it is still running).
+func (a *AnimatorWidget) stopPlayback(state *animatorState, stop chan bool) {
+	state.m.Lock()
+
+	if !state.isRunning || state.stop != stop {
+		state.m.Unlock()
+
+		return
+	}
+
+	state.endPlayback()
+	state.isRunning = false
+	state.isPaused = false
+	state.isReversed = false
+	state.isPingPong = false
+	state.elapsed = 0
+	state.queue = nil
+	state.repeatsLeft = 0
+
+	state.m.Unlock()
+
+	callback(a.onCancel)
+
+	giu.Update()
+}
+
+// SetKeyFrame sets the current key frame without animating.
+// If the animation is running, it gets stopped (see Stop) first.
+// It could be used e.g. to restore a saved page of TransitionAnimation.
+func (a *AnimatorWidget) SetKeyFrame(kf KeyFrame) {
+	if err := a.validateKeyFrames(kf); err != nil {
+		reportError(err)
+
+		return
+	}
+
+	a.Stop()
+
+	state := a.getState()
+
+	state.m.Lock()
+	state.currentKeyFrame = kf
+	state.destinationKeyFrame = kf
+	state.longTimeDestinationKeyFrame = kf
+	state.m.Unlock()
+
+	giu.Update()
+}
+
+// interrupt applies animator's InterruptionPolicy if the animation is running.
+// restart is a Start* call that should be queued if necessary and isReversal
+// tells whether this call should reverse the running animation (see InterruptReverse).
+// It returns true if the caller should not start the animation.
+func (a *AnimatorWidget) interrupt(restart startCall, isReversal func(s *animatorState) bool) bool {
+	if a.interruption == InterruptRestart {
+		return false
+	}
+
+	state := a.getState()
+
+	state.m.Lock()
+	defer state.m.Unlock()
+
+	if !state.isRunning {
+		return false
+	}
+
+	switch a.interruption {
+	case InterruptRestart, InterruptIgnore:
+		// noop
+	case InterruptQueue:
+		state.queue = append(state.queue, restart)
+	case InterruptReverse:
+		if !isReversal(state) {
+			break
+		}
+
+		state.update(a.clock.Now(), a.timeScale())
+		p := state.progress()
+		state.isReversed = !state.isReversed
+		state.setProgress(p)
+
+		state.numberOfCycles = 0
+		state.isPingPong = false
+		state.repeatsLeft = 0
+		state.longTimeDestinationKeyFrame = state.destinationKeyFrame
+
+		if state.isReversed {
+			state.longTimeDestinationKeyFrame = state.currentKeyFrame
+		}
+	}
+
+	return true
+}
+
+// isOppositeTo returns a function telling if the animation is played in direction opposite to playMode.
+func isOppositeTo(playMode PlayMode) func(s *animatorState) bool {
+	return func(s *animatorState) bool {
+		return s.direction() != playMode.direction()
+	}
+}
+
+// Pause pauses currently running animation. It does nothing if animation is not running.
+// Use Resume to continue playback.
+func (a *AnimatorWidget) Pause() {
+	state := a.getState()
+
+	state.m.Lock()
+	defer state.m.Unlock()
+
+	if !state.isRunning {
+		return
+	}
+
+	state.update(a.clock.Now(), a.timeScale())
+	state.isPaused = true
+}
+
+// Resume resumes animation paused by Pause.
+func (a *AnimatorWidget) Resume() {
+	state := a.getState()
+
+	state.m.Lock()
+
+	if !state.isRunning || !state.isPaused {
+		state.m.Unlock()
+
+		return
+	}
+
+	state.update(a.clock.Now(), a.timeScale())
+	state.isPaused = false
+
+	state.m.Unlock()
+
+	giu.Update()
+}
+
+// Seek sets progress (0 <= progress <= 1) of the current key frame.
+// If animation is not running, Seek will start a paused playback from the current
+// key frame to the next one (as if Start(PlayForward) was called) and then seek to progress.
+// Pause/Resume state of running animation is kept.
+func (a *AnimatorWidget) Seek(progress float32) {
+	a.startPausedIfIdle()
+
+	state := a.getState()
+
+	state.m.Lock()
+
+	if !state.isRunning {
+		state.m.Unlock()
+
+		return
+	}
+
+	state.update(a.clock.Now(), a.timeScale())
+	state.setProgress(progress)
+
+	state.m.Unlock()
+
+	giu.Update()
+}
+
+// SeekKeyFrame moves playback to the beginning of kf. Animation will be played from kf
+// to the next key frame (in current play mode) and then towards its destination.
+// If animation is not running, SeekKeyFrame will start a paused playback
+// from kf to the next key frame (as if Start(PlayForward) was called).
+// Pause/Resume state of running animation is kept.
+// If there is nothing to play from kf (see ClampKeyFrames), the animation stops on kf.
+func (a *AnimatorWidget) SeekKeyFrame(kf KeyFrame) {
+	if err := a.validateKeyFrames(kf); err != nil {
+		reportError(err)
+
+		return
+	}
+
+	idle := a.startPausedIfIdle()
+
+	state := a.getState()
+
+	state.m.Lock()
+
+	if !state.isRunning {
+		state.m.Unlock()
+
+		return
+	}
+
+	if !a.canAdvanceFrom(kf, state.playMode) {
+		// nothing to play from kf
+		state.currentKeyFrame = kf
+		state.m.Unlock()
+
+		a.Stop()
+
+		return
+	}
+
+	state.update(a.clock.Now(), a.timeScale())
+	state.elapsed = 0
+	state.isReversed = false
+	state.isDirect = false
+	state.currentKeyFrame = kf
+	state.destinationKeyFrame = getWithDelta(kf, a.numKeyFrames, playModeDelta(state.playMode))
+	a.applyTiming(state)
+
+	if idle {
+		state.longTimeDestinationKeyFrame = state.destinationKeyFrame
+	}
+
+	state.m.Unlock()
+
+	giu.Update()
+}
+
+// startPausedIfIdle starts a paused playback (of the next key frame) if animation is not running.
+// It returns true if it was idle.
+func (a *AnimatorWidget) startPausedIfIdle() bool {
+	if a.IsRunning() {
+		return false
+	}
+
+	a.Start(PlayForward)
+	a.Pause()
+
+	return true
 }
 
 // internal start method. Stops animator if running and re-initializes it.
-// It will call playAnimation in a new goroutine.
-func (a *AnimatorWidget) start(playMode PlayMode) {
+// Depending on animator's Driver, it will pass the animation to the shared scheduler
+// or leave advancing the animation to Build.
+func (a *AnimatorWidget) start(ctx context.Context, playMode PlayMode) {
 	a.animation.Reset()
 	state := a.getState()
 
 	state.m.Lock()
 
-	if state.isRunning {
-		state.reset <- true
+	if state.isDisposed {
+		// e.g. animator returned by Animators after it stopped being built.
+		state.m.Unlock()
+
+		return
 	}
 
-	state.isRunning = true
-	state.duration = a.duration
+	cancelled := state.isRunning
+	if cancelled {
+		state.endPlayback()
+	}
+
+	// repetitions are parts of the same playback
+	started := !state.isRepeating
+	state.isRepeating = false
+
+	state.isPaused = false
+	state.isReversed = false
+	state.elapsed = -a.delay
+	state.lastUpdate = a.clock.Now()
 
 	state.playMode = playMode
+	a.applyTiming(state)
+
+	// direct jumps never wrap, so they are not affected by ClampKeyFrames
+	canAdvance := state.isDirect || a.canAdvanceFrom(state.currentKeyFrame, playMode)
+
+	if !canAdvance || state.reachedDestination() {
+		state.isRunning = false
+		state.m.Unlock()
+
+		if cancelled {
+			callback(a.onCancel)
+		}
+
+		return
+	}
+
+	state.isRunning = true
+	stop := state.stop
 
 	state.m.Unlock()
 
-	go a.playAnimation(playMode)
+	if cancelled {
+		callback(a.onCancel)
+	}
+
+	if started {
+		callback(a.onStart)
+	}
+
+	if ctx.Done() != nil {
+		go a.watchContext(ctx, state, stop)
+	}
+
+	sharedScheduler.schedule(a, state, stop)
+
+	if a.driver == DriverFrame {
+		giu.Update()
+	}
 }
 
-// playAnimation is where the animation is plaid.
-// It runs a for loop through all the frames that it should go.
-// It will exit if any message received on state.reset.
-func (a *AnimatorWidget) playAnimation(playMode PlayMode) {
-	state := a.getState()
+// watchContext stops playback identified by stop when ctx gets cancelled.
+// It exits when the playback is over.
+func (a *AnimatorWidget) watchContext(ctx context.Context, state *animatorState, stop chan bool) {
+	select {
+	case <-ctx.Done():
+		a.stopPlayback(state, stop)
+	case <-stop:
+	}
+}
+
+// advance moves playback identified by stop to the time now.
+// When elapsed time exceeds duration, it goes to the next key frame.
+// It returns false when the playback is over (or was replaced by another one).
+// When playback finishes, the first of queued Start* calls (see InterruptQueue) is invoked.
+func (a *AnimatorWidget) advance(state *animatorState, stop chan bool, now time.Time) bool {
 	state.m.Lock()
 
-	resetChan := state.reset
+	if !state.isRunning || state.stop != stop {
+		state.m.Unlock()
+
+		return false
+	}
+
+	running, reached := a.advanceLocked(state, now)
+
+	if running {
+		state.m.Unlock()
+
+		a.keyFramesReached(reached)
+
+		return true
+	}
+
+	var (
+		next      startCall
+		repeating bool
+	)
+
+	state.endPlayback()
+
+	switch {
+	case state.repeatsLeft != 0:
+		if state.repeatsLeft > 0 {
+			state.repeatsLeft--
+		}
+
+		next = a.repeatFunc(state, now)
+		repeating = true
+	case len(state.queue) > 0:
+		next = state.queue[0]
+		state.queue = state.queue[1:]
+	}
+
 	state.m.Unlock()
 
-	for {
+	a.keyFramesReached(reached)
+
+	// repetitions are parts of the same playback
+	if !repeating {
+		callback(a.onFinish)
+	}
+
+	if next != nil {
+		next(a)
+	}
+
+	return false
+}
+
+// keyFramesReached calls OnKeyFrameReached callback for all key frames reached.
+func (a *AnimatorWidget) keyFramesReached(reached []KeyFrame) {
+	if a.onKeyFrameReached == nil {
+		return
+	}
+
+	for _, kf := range reached {
+		a.onKeyFrameReached(kf)
+	}
+}
+
+// repeatFunc returns a function that repeats the animation (see animatorState.rerun)
+// and keeps repeatsLeft (as rerun resets it).
+// The repeated animation waits for animator's repeat delay
+// counted from now (when the previous one finished).
+// It must be called with state.m locked.
+func (a *AnimatorWidget) repeatFunc(state *animatorState, now time.Time) startCall {
+	rerun, repeatsLeft := state.rerun, state.repeatsLeft
+	overshoot := state.elapsed
+
+	return func(animator *AnimatorWidget) {
 		state.m.Lock()
-		state.elapsed = 0
+		state.isRepeating = true
+		state.m.Unlock()
 
-		if state.currentKeyFrame == state.longTimeDestinationKeyFrame {
-			if state.numberOfCycles == 0 {
-				state.m.Unlock()
+		rerun(animator)
+
+		state.m.Lock()
+		defer state.m.Unlock()
+
+		state.repeatsLeft = repeatsLeft
+
+		if state.isRunning {
+			state.elapsed = overshoot - a.repeatDelay
+			state.lastUpdate = now
+		}
+	}
+}
 
+// advanceLocked is a part of advance called with state.m locked.
+// Time exceeding duration of a key frame is carried over to the next one, so
+// (if advanced rarely) the animation may go through several key frames at once.
+// It returns false when the playback is over and reached contains key frames reached.
+func (a *AnimatorWidget) advanceLocked(state *animatorState, now time.Time) (running bool, reached []KeyFrame) {
+	state.update(now, a.timeScale())
+
+	// key frames with no duration are reached at once, but at most one pass
+	// through all key frames is done in one call so that it always returns.
+	instant := KeyFrame(0)
+
+	for state.elapsed >= state.duration {
+		if state.duration > 0 {
+			state.elapsed -= state.duration
+		} else {
+			if instant >= a.numKeyFrames {
 				break
 			}
 
-			state.numberOfCycles--
+			instant++
+			state.elapsed = 0
 		}
 
-		state.m.Unlock()
+		running := a.nextKeyFrame(state)
+		reached = append(reached, state.currentKeyFrame)
 
-		tickDuration := time.Second / time.Duration(a.fps)
-		ticker := time.NewTicker(tickDuration)
+		if !running {
+			return false, reached
+		}
+	}
 
-	AnimationLoop:
-		for {
-			select {
-			case <-ticker.C:
-				giu.Update()
-				state.m.Lock()
+	return true, reached
+}
 
-				if state.elapsed >= state.duration {
-					ticker.Stop()
+// nextKeyFrame moves the animation to its next key frame.
+// It returns false when the playback is over.
+// It must be called with state.m locked.
+func (a *AnimatorWidget) nextKeyFrame(state *animatorState) bool {
+	if state.isReversed {
+		// reversed playback ends where its key frame started.
+		state.isReversed = false
+		state.isRunning = false
 
-					state.elapsed = 0
+		return false
+	}
 
-					// call update last time to build animation normally at least once (before Power Saving Mechanism freezes updating)
-					// This is important mainly because of triggers that might have to be run.
-					giu.Update()
+	state.currentKeyFrame = state.destinationKeyFrame
+	state.destinationKeyFrame = getWithDelta(state.currentKeyFrame, a.numKeyFrames, playModeDelta(state.playMode))
+	a.applyTiming(state)
 
-					var delta KeyFrame = 1
-					if playMode == PlayBackward {
-						delta = -1
-					}
+	if !a.canAdvanceFrom(state.currentKeyFrame, state.playMode) {
+		// key frames don't wrap - it is the end.
+		state.longTimeDestinationKeyFrame = state.currentKeyFrame
+		state.numberOfCycles = 0
+	}
 
-					state.currentKeyFrame = getWithDelta(state.currentKeyFrame, a.numKeyFrames, delta)
-					state.destinationKeyFrame = getWithDelta(state.currentKeyFrame, a.numKeyFrames, delta)
+	isEndOfCycle := state.currentKeyFrame == state.longTimeDestinationKeyFrame
 
-					state.m.Unlock()
+	if !state.reachedDestination() {
+		if isEndOfCycle {
+			state.elapsed -= a.cycleDelay
+		}
 
-					break AnimationLoop
-				}
+		return true
+	}
 
-				state.elapsed += tickDuration
+	if !state.isPingPong || state.playMode == PlayBackward {
+		state.isRunning = false
 
-				state.m.Unlock()
-			case <-resetChan:
-				return
-			}
-		}
+		return false
 	}
 
-	state.m.Lock()
-	state.isRunning = false
-	state.m.Unlock()
+	// go back to where ping-pong started
+	state.playMode = PlayBackward
+	state.longTimeDestinationKeyFrame = state.pingPongOrigin
+	state.destinationKeyFrame = getWithDelta(state.currentKeyFrame, a.numKeyFrames, -1)
+	state.numberOfCycles = state.pingPongCycles
+
+	if state.isDirect {
+		state.destinationKeyFrame = state.pingPongOrigin
+		state.numberOfCycles = 0
+	}
+
+	a.applyTiming(state)
+
+	if state.reachedDestination() {
+		state.isRunning = false
+
+		return false
+	}
+
+	return true
+}
+
+// applyTiming sets duration and easing algorithm of the current key frame.
+// They may be overridden by the animation (see KeyFrameTimer) and reduced motion (see MotionReducer).
+// It must be called with state.m locked.
+func (a *AnimatorWidget) applyTiming(state *animatorState) {
+	state.duration, state.easingAlgorithm = a.duration, a.easingAlgorithm
+	state.easingFunc = a.easingFunc
+
+	defer a.reduceMotion(state)
+
+	timer, ok := a.animation.(KeyFrameTimer)
+	if !ok {
+		return
+	}
+
+	// timing of a key frame applies to the transition from the previous key frame.
+	kf := state.destinationKeyFrame
+	if state.playMode == PlayBackward {
+		kf = state.currentKeyFrame
+	}
+
+	timing := timer.KeyFrameTiming(kf)
+
+	if timing.Duration > 0 {
+		state.duration = timing.Duration
+	}
+
+	if timing.OverrideEasing {
+		state.easingAlgorithm = timing.EasingAlgorithm
+		state.easingFunc = nil
+	}
+}
+
+// initialize validates the animator and initializes its animation when it is built for the first time.
+// Invalid animators are validated again on every Build, so that they get built as soon as
+// their configuration is fixed (e.g. when data of the animation gets loaded).
+// The same error is reported only once. It returns false if the animator is invalid.
+func (a *AnimatorWidget) initialize(s *animatorState) bool {
+	s.m.Lock()
+	shouldInit, prevErr := s.shouldInit, s.err
+	s.m.Unlock()
+
+	if !shouldInit && prevErr == nil {
+		return true
+	}
+
+	err := a.Validate()
+
+	switch {
+	case err == nil:
+		a.animation.Init()
+	case prevErr == nil || err.Error() != prevErr.Error():
+		reportError(fmt.Errorf("animator %s: %w", a.id, err))
+	}
+
+	s.m.Lock()
+	s.shouldInit = false
+	s.err = err
+	s.m.Unlock()
+
+	return err == nil
+}
+
+// checkKeyFrames makes sure that key frames of the state are in range of the animation.
+// The animation is rebuilt every frame, so it may have fewer key frames than it had
+// when the state was set up (e.g. after its data got reloaded).
+// In such a case the error is reported, the animation is stopped and
+// its key frames are clamped to the last one.
+func (a *AnimatorWidget) checkKeyFrames(s *animatorState) {
+	s.m.Lock()
+	err := a.validateKeyFrames(s.currentKeyFrame, s.destinationKeyFrame, s.longTimeDestinationKeyFrame)
+	stop := s.stop
+	s.m.Unlock()
+
+	if err == nil {
+		return
+	}
+
+	reportError(err)
+	a.stopPlayback(s, stop)
+
+	last := a.numKeyFrames - 1
+
+	s.m.Lock()
+	s.currentKeyFrame = min(s.currentKeyFrame, last)
+	s.destinationKeyFrame = min(s.destinationKeyFrame, last)
+	s.longTimeDestinationKeyFrame = min(s.longTimeDestinationKeyFrame, last)
+	s.m.Unlock()
 }
 
 // Build implements giu.Widget.
 func (a *AnimatorWidget) Build() {
 	s := a.getState()
 
-	if a.shouldInit() {
-		a.animation.Init()
-		s.m.Lock()
-		s.shouldInit = false
-		s.m.Unlock()
+	registry.register(a, s)
+
+	if !a.initialize(s) {
+		// invalid animation could crash while building.
+		return
 	}
 
+	a.checkKeyFrames(s)
+
+	// the animation is advanced to the time of this frame with every Driver,
+	// so that key frames are reached on time. DriverTicker only caps redraws to FPS.
 	s.m.Lock()
+	stop := s.stop
+	s.m.Unlock()
+
+	if a.advance(s, stop, a.clock.Now()) && a.driver == DriverFrame && !a.IsPaused() && a.timeScale() > 0 {
+		// request next frame so that the animation keeps going.
+		giu.Update()
+	}
+
+	s.m.Lock()
+
+	isRunning := s.isRunning
+	if isRunning {
+		s.update(a.clock.Now(), a.timeScale())
+	}
+
+	isWaiting := s.isWaiting()
+	p := s.progress()
 	cf, df := s.currentKeyFrame, s.destinationKeyFrame
 	playMode := s.playMode
+	easingAlgorithm, easingFunc := s.easingAlgorithm, s.easingFunc
 	s.m.Unlock()
 
-	if a.IsRunning() {
-		p := a.CurrentPercentageProgress()
+	if isRunning && !isWaiting {
+		eased := Ease(easingAlgorithm, p)
+		if easingFunc != nil {
+			eased = easingFunc(p)
+		}
+
 		a.animation.BuildAnimation(
-			Ease(a.easingAlgorithm, p), p,
+			eased, p,
 			cf, df,
 			playMode,
 			a,
 		)
-
-		return
+	} else {
+		a.animation.BuildNormal(cf, a)
 	}
 
-	a.animation.BuildNormal(cf, a)
+	switch {
+	case !isRunning:
+		a.checkTrigger(s)
+	case a.interruption == InterruptReverse && a.triggerType == TriggerOnChange:
+		// with InterruptReverse, changes of the trigger reverse running animation.
+		a.checkTrigger(s)
+	}
+}
 
-	if a.triggerFunc != nil {
-		triggerValue := a.triggerFunc()
+// checkTrigger starts the animation if animator's trigger says so.
+func (a *AnimatorWidget) checkTrigger(s *animatorState) {
+	if a.triggerFunc == nil {
+		return
+	}
 
-		switch a.triggerType {
-		case TriggerNever:
-			// noop
-		case TriggerOnTrue:
-			if triggerValue {
-				a.Start(a.triggerPlyMode)
-			}
-		case TriggerOnChange:
-			s.m.Lock()
-			triggerStatus := s.triggerStatus
-			s.m.Unlock()
+	triggerValue := a.triggerFunc()
 
-			if triggerStatus != triggerValue {
-				a.Start(a.triggerPlyMode)
-			}
+	switch a.triggerType {
+	case TriggerNever:
+		// noop
+	case TriggerOnTrue:
+		if triggerValue {
+			a.Start(a.triggerPlyMode)
+		}
+	case TriggerOnChange:
+		s.m.Lock()
+		triggerStatus := s.triggerStatus
+		s.m.Unlock()
 
-			s.m.Lock()
-			s.triggerStatus = triggerValue
-			s.m.Unlock()
+		if triggerStatus != triggerValue {
+			a.Start(a.triggerPlyMode)
 		}
+
+		s.m.Lock()
+		s.triggerStatus = triggerValue
+		s.m.Unlock()
 	}
 }
@@ -0,0 +1,55 @@
+package animations
+
+// Lifecycle callbacks.
+//
+// Callbacks are called outside of animator's internal lock, so it is safe
+// to call AnimatorWidget's methods (e.g. Start) from them.
+// They are called on the following goroutines:
+//   - OnStart and OnCancel - on the goroutine calling the method that started/interrupted
+//     the animation (usually the render thread, e.g. inside a button's callback or a trigger).
+//     Queued animations (see InterruptQueue) are started by the goroutine advancing the animation.
+//     Animations stopped by cancelling their context (see StartContext) call OnCancel
+//     on a goroutine watching the context.
+//   - OnKeyFrameReached and OnFinish - on the goroutine advancing the animation:
+//     the render thread (inside Build) or, with DriverTicker, also the scheduler's goroutine.
+//
+// NOTE: callbacks running outside of the render thread must not call imgui.
+// If you need to change your UI, store the information and call giu.Update.
+
+// OnStart sets a callback called when the animation starts playing.
+// Repetitions (see Repeat) do not call it again.
+func (a *AnimatorWidget) OnStart(f func()) *AnimatorWidget {
+	a.onStart = f
+
+	return a
+}
+
+// OnKeyFrameReached sets a callback called whenever the animation reaches a key frame.
+func (a *AnimatorWidget) OnKeyFrameReached(f func(kf KeyFrame)) *AnimatorWidget {
+	a.onKeyFrameReached = f
+
+	return a
+}
+
+// OnFinish sets a callback called when the animation finishes naturally
+// (after all repetitions - see Repeat).
+func (a *AnimatorWidget) OnFinish(f func()) *AnimatorWidget {
+	a.onFinish = f
+
+	return a
+}
+
+// OnCancel sets a callback called when running animation gets interrupted
+// (e.g. by Stop or by restarting it - see InterruptRestart).
+func (a *AnimatorWidget) OnCancel(f func()) *AnimatorWidget {
+	a.onCancel = f
+
+	return a
+}
+
+// callback calls f if it is not nil.
+func callback(f func()) {
+	if f != nil {
+		f()
+	}
+}
@@ -1,7 +1,7 @@
 package animations
 
 import (
-	"log"
+	"fmt"
 	"sync"
 	"time"
 
@@ -10,12 +10,36 @@ import (
 
 var _ giu.Disposable = &animatorState{}
 
+// startCall is a Start* call. The animator is passed, so that the call is made
+// on the animator advancing the animation (which may be bound to its state, see Animators).
+type startCall func(animator *AnimatorWidget)
+
 type animatorState struct {
+	// id of the animator (see registry)
+	id giu.ID
+
+	// err is set if the animator failed to validate (see (*AnimatorWidget).Validate).
+	// Invalid animators are not built (they are validated again on every Build).
+	err error
+
 	shouldInit bool
+	isDisposed bool
 	isRunning  bool
+	isPaused   bool
+	// isReversed is set when the current key frame is played backwards
+	// (from 1 to 0) after interruption (see InterruptReverse).
+	isReversed bool
 
+	// elapsed time of the current key frame. Negative value means that the animation waits
+	// (see (*AnimatorWidget).Delay).
 	elapsed  time.Duration
 	duration time.Duration
+	// easing algorithm of the current key frame
+	easingAlgorithm EasingAlgorithmType
+	// easingFunc is used instead of easingAlgorithm if set (see (*AnimatorWidget).EasingFunc).
+	easingFunc EasingAlgorithm
+	// lastUpdate is a clock reading of the last elapsed update.
+	lastUpdate time.Time
 
 	triggerStatus bool
 
@@ -24,22 +48,163 @@ type animatorState struct {
 	longTimeDestinationKeyFrame,
 	destinationKeyFrame KeyFrame
 	playMode PlayMode
+	// isDirect is true if the animation goes from current key frame
+	// straight to long time destination (see KeyFramePathDirect).
+	isDirect bool
+
+	// ping-pong (see PlayPingPong)
+	isPingPong     bool
+	pingPongOrigin KeyFrame
+	pingPongCycles int
 
-	reset chan bool
-	m     *sync.Mutex
+	// rerun is the last Start* call. It is used to repeat the animation.
+	rerun       startCall
+	repeatsLeft int
+	isRepeating bool
+
+	// queue holds Start* calls postponed by InterruptQueue.
+	queue []startCall
+
+	// stop is closed when the current playback gets interrupted.
+	stop chan bool
+	m    *sync.Mutex
 }
 
 // Dispose implements giu.Disposable.
+// It is called by giu when the animator is no longer built.
+// It stops running playback (so that its goroutine exits), drops
+// queued Start* calls and repetitions and removes the animator from Animators.
+// Callbacks are not invoked.
+//
+// giu disposes states from any GetState call, so Dispose may be called while s.m is held
+// (e.g. by a KeyFrameTimer reading its giu state while the animation is advanced).
+// In such a case the playback is stopped as soon as s.m is released.
 func (s *animatorState) Dispose() {
-	// noop
+	registry.unregister(s)
+
+	if s.m.TryLock() {
+		defer s.m.Unlock()
+
+		s.disposeLocked()
+
+		return
+	}
+
+	go func() {
+		s.m.Lock()
+		defer s.m.Unlock()
+
+		s.disposeLocked()
+	}()
+}
+
+// disposeLocked implements Dispose. It must be called with s.m locked.
+func (s *animatorState) disposeLocked() {
+	s.isDisposed = true
+
+	if s.isRunning {
+		s.endPlayback()
+	}
+
+	s.isRunning = false
+	s.isPaused = false
+	s.queue = nil
+	s.rerun = nil
+	s.repeatsLeft = 0
 }
 
 func (a *AnimatorWidget) newState() *animatorState {
 	return &animatorState{
+		id:         a.id,
 		shouldInit: true,
 		m:          &sync.Mutex{},
-		reset:      make(chan bool),
+		stop:       make(chan bool),
+	}
+}
+
+// endPlayback closes stop of the current playback, so that everything waiting for it exits,
+// and removes the playback from the scheduler.
+// It must be called with s.m locked and only once per playback.
+func (s *animatorState) endPlayback() {
+	close(s.stop)
+	s.stop = make(chan bool)
+
+	sharedScheduler.unschedule(s)
+}
+
+// update adds time passed since the last update (multiplied by scale) to elapsed.
+// Time does not pass when paused.
+// It must be called with s.m locked.
+func (s *animatorState) update(now time.Time, scale float64) {
+	if !s.isPaused {
+		s.elapsed += scaleDuration(now.Sub(s.lastUpdate), scale)
 	}
+
+	s.lastUpdate = now
+}
+
+// reachedDestination returns true if current key frame is the destination
+// and there are no more cycles to play. Otherwise, if current key frame is the destination,
+// one cycle is consumed.
+// It must be called with s.m locked.
+func (s *animatorState) reachedDestination() bool {
+	if s.currentKeyFrame != s.longTimeDestinationKeyFrame {
+		return false
+	}
+
+	if s.numberOfCycles == 0 {
+		return true
+	}
+
+	s.numberOfCycles--
+
+	return false
+}
+
+// progress returns progress of the current key frame.
+// It must be called with s.m locked.
+func (s *animatorState) progress() float32 {
+	result := float32(1)
+	if s.duration > 0 {
+		result = clamp01(float32(s.elapsed) / float32(s.duration))
+	}
+
+	if s.isReversed {
+		return 1 - result
+	}
+
+	return result
+}
+
+// setProgress sets elapsed time so that progress() returns p.
+// It must be called with s.m locked.
+func (s *animatorState) setProgress(p float32) {
+	p = clamp01(p)
+	if s.isReversed {
+		p = 1 - p
+	}
+
+	s.elapsed = time.Duration(float32(s.duration) * p)
+}
+
+// isWaiting returns true if running animation waits for a delay.
+// It must be called with s.m locked.
+func (s *animatorState) isWaiting() bool {
+	return s.isRunning && s.elapsed < 0
+}
+
+// direction returns a direction the animation is actually played in.
+// It must be called with s.m locked.
+func (s *animatorState) direction() PlayMode {
+	if !s.isReversed {
+		return s.playMode
+	}
+
+	if s.playMode == PlayForward {
+		return PlayBackward
+	}
+
+	return PlayForward
 }
 
 // getState returns animator's state.
@@ -47,13 +212,18 @@ func (a *AnimatorWidget) newState() *animatorState {
 // There is animation bunch of Animator's methods that allows
 // user to obtain certain data.
 func (a *AnimatorWidget) getState() *animatorState {
+	if a.state != nil {
+		return a.state
+	}
+
 	if s := giu.Context.GetState(a.id); s != nil {
 		state, ok := s.(*animatorState)
-		if !ok {
-			log.Panicf("error asserting type of animator state: got %T, wanted *animatorState", s)
+		if ok {
+			return state
 		}
 
-		return state
+		// replace the state so that the animator keeps working.
+		reportError(fmt.Errorf("animator %s: %w: got %T, wanted *animatorState", a.id, ErrInvalidState, s))
 	}
 
 	giu.Context.SetState(a.id, a.newState())
@@ -71,18 +241,31 @@ func (a *AnimatorWidget) IsRunning() bool {
 	return s.isRunning
 }
 
-func (a *AnimatorWidget) shouldInit() bool {
+// IsPaused returns true if the animation is running but paused.
+func (a *AnimatorWidget) IsPaused() bool {
 	s := a.getState()
 
 	s.m.Lock()
 	defer s.m.Unlock()
 
-	return s.shouldInit
+	return s.isRunning && s.isPaused
+}
+
+// CurrentKeyFrame returns the current key frame.
+// While the animation is running, it is the key frame the animation is played from.
+func (a *AnimatorWidget) CurrentKeyFrame() KeyFrame {
+	s := a.getState()
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.currentKeyFrame
 }
 
 // CurrentPercentageProgress returns animation float value from range <0, 1>
 // representing current progress of an animation.
 // If animation is not running, it will return 0.
+// Progress is measured with animator's Clock.
 func (a *AnimatorWidget) CurrentPercentageProgress() float32 {
 	if !a.IsRunning() {
 		return 0
@@ -93,10 +276,7 @@ func (a *AnimatorWidget) CurrentPercentageProgress() float32 {
 	s.m.Lock()
 	defer s.m.Unlock()
 
-	result := float32(s.elapsed) / float32(s.duration)
-	if result > 1 {
-		return 1
-	}
+	s.update(a.clock.Now(), a.timeScale())
 
-	return result
+	return s.progress()
 }
@@ -0,0 +1,887 @@
+package animations
+
+import (
+	"context"
+	"math"
+	"os"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/AllenDang/giu"
+)
+
+func TestMain(m *testing.M) {
+	imgui.CreateContext()
+
+	giu.Context = giu.CreateContext(nil)
+
+	os.Exit(m.Run())
+}
+
+var _ Animation = &testAnimation{}
+
+// testAnimation records arguments of the last Build* call.
+type testAnimation struct {
+	numKeyFrames KeyFrame
+
+	m              *sync.Mutex
+	normalCalls    int
+	animationCalls int
+	percentage     float32
+	arbitrary      float32
+	base, dest     KeyFrame
+	mode           PlayMode
+}
+
+func newTestAnimation(numKeyFrames KeyFrame) *testAnimation {
+	return &testAnimation{
+		numKeyFrames: numKeyFrames,
+		m:            &sync.Mutex{},
+	}
+}
+
+func (t *testAnimation) Init()  {}
+func (t *testAnimation) Reset() {}
+
+func (t *testAnimation) KeyFramesCount() KeyFrame {
+	return t.numKeyFrames
+}
+
+func (t *testAnimation) BuildNormal(kf KeyFrame, _ StarterFunc) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.normalCalls++
+	t.base = kf
+}
+
+func (t *testAnimation) BuildAnimation(p, ap float32, base, dest KeyFrame, mode PlayMode, _ StarterFunc) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.animationCalls++
+	t.percentage, t.arbitrary = p, ap
+	t.base, t.dest = base, dest
+	t.mode = mode
+}
+
+func newTestAnimator(t *testing.T, numKeyFrames KeyFrame) (*AnimatorWidget, *testAnimation, *FakeClock) {
+	t.Helper()
+
+	anim := newTestAnimation(numKeyFrames)
+	clock := NewFakeClock(time.Unix(0, 0))
+	animator := Animator(anim).
+		ID(giu.ID(t.Name())).
+		Clock(clock).
+		Duration(time.Second).
+		FPS(100)
+
+	return animator, anim, clock
+}
+
+// advanceUntilStopped advances clock until the animator stops playback.
+func advanceUntilStopped(t *testing.T, a *AnimatorWidget, clock *FakeClock) {
+	t.Helper()
+
+	for i := 0; i < 10000; i++ {
+		if !a.IsRunning() {
+			return
+		}
+
+		clock.Advance(10 * time.Millisecond)
+		runtime.Gosched()
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatal("animation did not stop")
+}
+
+func almostEqual(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 1e-5
+}
+
+func TestAnimatorWidget_FakeClockProgress(t *testing.T) {
+	a, anim, clock := newTestAnimator(t, 3)
+	a.EasingAlgorithm(EasingAlgInQuad)
+
+	a.Start(PlayForward)
+
+	if !a.IsRunning() {
+		t.Fatal("animation should be running after Start")
+	}
+
+	clock.Advance(370 * time.Millisecond)
+
+	if p := a.CurrentPercentageProgress(); !almostEqual(p, 0.37) {
+		t.Errorf("CurrentPercentageProgress() = %v, want 0.37", p)
+	}
+
+	a.Build()
+
+	anim.m.Lock()
+	if anim.animationCalls != 1 {
+		t.Errorf("BuildAnimation called %d times, want 1", anim.animationCalls)
+	}
+
+	if !almostEqual(anim.arbitrary, 0.37) {
+		t.Errorf("BuildAnimation received arbitrary percentage %v, want 0.37", anim.arbitrary)
+	}
+
+	if want := Ease(EasingAlgInQuad, 0.37); !almostEqual(anim.percentage, want) {
+		t.Errorf("BuildAnimation received percentage %v, want %v", anim.percentage, want)
+	}
+
+	if anim.base != 0 || anim.dest != 1 || anim.mode != PlayForward {
+		t.Errorf("BuildAnimation received %v -> %v (mode %v), want 0 -> 1 (mode %v)", anim.base, anim.dest, anim.mode, PlayForward)
+	}
+	anim.m.Unlock()
+
+	advanceUntilStopped(t, a, clock)
+
+	a.Build()
+
+	anim.m.Lock()
+	defer anim.m.Unlock()
+
+	if anim.normalCalls != 1 || anim.base != 1 {
+		t.Errorf("BuildNormal should be called with key frame 1; got %d calls with %v", anim.normalCalls, anim.base)
+	}
+}
+
+func TestFakeClock_Ticker(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ticker := clock.NewTicker(time.Second)
+
+	clock.Advance(time.Second / 2)
+
+	select {
+	case <-ticker.C():
+		t.Fatal("ticker fired too early")
+	default:
+	}
+
+	clock.Advance(time.Second / 2)
+
+	select {
+	case now := <-ticker.C():
+		if !now.Equal(time.Unix(1, 0)) {
+			t.Errorf("ticker delivered %v, want %v", now, time.Unix(1, 0))
+		}
+	default:
+		t.Fatal("ticker did not fire")
+	}
+
+	ticker.Stop()
+	clock.Advance(time.Second)
+
+	select {
+	case <-ticker.C():
+		t.Fatal("stopped ticker fired")
+	default:
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewTicker should panic on non-positive interval")
+		}
+	}()
+
+	clock.NewTicker(0)
+}
+
+func TestAnimatorWidget_DriverFrame(t *testing.T) {
+	a, anim, clock := newTestAnimator(t, 3)
+	a.Driver(DriverFrame)
+
+	a.StartKeyFrames(0, 2, 0, PlayForward)
+
+	clock.Advance(250 * time.Millisecond)
+	a.Build()
+
+	anim.m.Lock()
+	if anim.animationCalls != 1 || !almostEqual(anim.percentage, 0.25) || anim.base != 0 || anim.dest != 1 {
+		t.Errorf("unexpected BuildAnimation call: %d calls, %v%% %v -> %v", anim.animationCalls, anim.percentage, anim.base, anim.dest)
+	}
+	anim.m.Unlock()
+
+	// time exceeding the key frame's duration is carried over to the next one
+	clock.Advance(time.Second)
+	a.Build()
+
+	anim.m.Lock()
+	if anim.animationCalls != 2 || !almostEqual(anim.percentage, 0.25) || anim.base != 1 || anim.dest != 2 {
+		t.Errorf("unexpected BuildAnimation call: %d calls, %v%% %v -> %v", anim.animationCalls, anim.percentage, anim.base, anim.dest)
+	}
+	anim.m.Unlock()
+
+	clock.Advance(time.Second)
+	a.Build()
+
+	if a.IsRunning() {
+		t.Fatal("animation should not be running after reaching destination")
+	}
+
+	anim.m.Lock()
+	defer anim.m.Unlock()
+
+	if anim.normalCalls != 1 || anim.base != 2 {
+		t.Errorf("BuildNormal should be called with key frame 2; got %d calls with %v", anim.normalCalls, anim.base)
+	}
+}
+
+func TestAnimatorWidget_PauseResume(t *testing.T) {
+	a, _, clock := newTestAnimator(t, 2)
+	a.Driver(DriverFrame)
+
+	a.Start(PlayForward)
+	clock.Advance(200 * time.Millisecond)
+	a.Pause()
+
+	if !a.IsPaused() {
+		t.Fatal("animation should be paused")
+	}
+
+	clock.Advance(time.Hour)
+	a.Build()
+
+	if p := a.CurrentPercentageProgress(); !almostEqual(p, 0.2) {
+		t.Errorf("progress changed while paused: got %v, want 0.2", p)
+	}
+
+	a.Resume()
+	clock.Advance(300 * time.Millisecond)
+
+	if p := a.CurrentPercentageProgress(); !almostEqual(p, 0.5) {
+		t.Errorf("CurrentPercentageProgress() = %v after resume, want 0.5", p)
+	}
+}
+
+func TestAnimatorWidget_Seek(t *testing.T) {
+	a, anim, _ := newTestAnimator(t, 4)
+	a.Driver(DriverFrame)
+
+	// seeking idle animator prepares a paused playback
+	a.Seek(0.6)
+
+	if !a.IsPaused() {
+		t.Fatal("Seek on idle animator should start paused playback")
+	}
+
+	a.Build()
+
+	anim.m.Lock()
+	if !almostEqual(anim.percentage, 0.6) || anim.base != 0 || anim.dest != 1 {
+		t.Errorf("unexpected BuildAnimation call: %v%% %v -> %v", anim.percentage, anim.base, anim.dest)
+	}
+	anim.m.Unlock()
+
+	a.StartKeyFrames(0, 3, 0, PlayBackward)
+	a.SeekKeyFrame(2)
+	a.Seek(0.5)
+	a.Build()
+
+	anim.m.Lock()
+	defer anim.m.Unlock()
+
+	if !almostEqual(anim.percentage, 0.5) || anim.base != 2 || anim.dest != 1 || anim.mode != PlayBackward {
+		t.Errorf("unexpected BuildAnimation call: %v%% %v -> %v (mode %v)", anim.percentage, anim.base, anim.dest, anim.mode)
+	}
+}
+
+func TestAnimatorWidget_InterruptReverse(t *testing.T) {
+	a, anim, clock := newTestAnimator(t, 2)
+	a.Driver(DriverFrame).
+		EasingAlgorithm(EasingAlgInQuad).
+		Interruption(InterruptReverse)
+
+	a.Start(PlayForward)
+	clock.Advance(300 * time.Millisecond)
+	a.Build()
+
+	// with 2 key frames, going forward from 1 leads back to 0
+	a.Start(PlayForward)
+	a.Build()
+
+	anim.m.Lock()
+	if !almostEqual(anim.arbitrary, 0.3) || anim.base != 0 || anim.dest != 1 {
+		t.Errorf("reversing should keep position: got %v%% %v -> %v", anim.arbitrary, anim.base, anim.dest)
+	}
+	anim.m.Unlock()
+
+	clock.Advance(100 * time.Millisecond)
+	a.Build()
+
+	anim.m.Lock()
+	if !almostEqual(anim.arbitrary, 0.2) || !almostEqual(anim.percentage, Ease(EasingAlgInQuad, 0.2)) {
+		t.Errorf("reversed animation should go back: got %v%% (eased %v)", anim.arbitrary, anim.percentage)
+	}
+	anim.m.Unlock()
+
+	clock.Advance(200 * time.Millisecond)
+	a.Build()
+
+	if a.IsRunning() {
+		t.Fatal("reversed animation should take only the remaining time")
+	}
+
+	anim.m.Lock()
+	defer anim.m.Unlock()
+
+	if anim.normalCalls != 1 || anim.base != 0 {
+		t.Errorf("reversed animation should end on key frame 0; got %d calls with %v", anim.normalCalls, anim.base)
+	}
+}
+
+func TestAnimatorWidget_InterruptQueue(t *testing.T) {
+	a, anim, clock := newTestAnimator(t, 3)
+	a.Driver(DriverFrame).Interruption(InterruptQueue)
+
+	a.Start(PlayForward)
+	clock.Advance(500 * time.Millisecond)
+	a.Start(PlayForward)
+	a.Build()
+
+	anim.m.Lock()
+	if !almostEqual(anim.arbitrary, 0.5) || anim.base != 0 || anim.dest != 1 {
+		t.Errorf("queued Start should not interrupt animation: got %v%% %v -> %v", anim.arbitrary, anim.base, anim.dest)
+	}
+	anim.m.Unlock()
+
+	clock.Advance(500 * time.Millisecond)
+	a.Build()
+
+	anim.m.Lock()
+	defer anim.m.Unlock()
+
+	if !a.IsRunning() || anim.base != 1 || anim.dest != 2 {
+		t.Errorf("queued Start should be invoked after animation finishes: got %v -> %v", anim.base, anim.dest)
+	}
+}
+
+func TestAnimatorWidget_InterruptIgnore(t *testing.T) {
+	a, anim, clock := newTestAnimator(t, 3)
+	a.Driver(DriverFrame).Interruption(InterruptIgnore)
+
+	a.Start(PlayForward)
+	clock.Advance(500 * time.Millisecond)
+	a.Start(PlayBackward)
+	a.Build()
+
+	anim.m.Lock()
+	defer anim.m.Unlock()
+
+	if !almostEqual(anim.arbitrary, 0.5) || anim.base != 0 || anim.dest != 1 {
+		t.Errorf("Start should be ignored: got %v%% %v -> %v", anim.arbitrary, anim.base, anim.dest)
+	}
+}
+
+func TestAnimatorWidget_PingPong(t *testing.T) {
+	a, anim, clock := newTestAnimator(t, 3)
+	a.Driver(DriverFrame)
+
+	a.StartKeyFrames(0, 2, 0, PlayPingPong)
+
+	want := []struct {
+		base, dest KeyFrame
+		mode       PlayMode
+	}{
+		{0, 1, PlayForward},
+		{1, 2, PlayForward},
+		{2, 1, PlayBackward},
+		{1, 0, PlayBackward},
+	}
+
+	for i, w := range want {
+		clock.Advance(time.Second / 2)
+		a.Build()
+
+		anim.m.Lock()
+		if anim.base != w.base || anim.dest != w.dest || anim.mode != w.mode {
+			t.Errorf("segment %d: got %v -> %v (mode %v), want %v -> %v (mode %v)", i, anim.base, anim.dest, anim.mode, w.base, w.dest, w.mode)
+		}
+		anim.m.Unlock()
+
+		clock.Advance(time.Second / 2)
+		a.Build()
+	}
+
+	if a.IsRunning() {
+		t.Fatal("ping-pong should stop on the key frame it started from")
+	}
+
+	anim.m.Lock()
+	defer anim.m.Unlock()
+
+	if anim.base != 0 {
+		t.Errorf("ping-pong should end on key frame 0, got %v", anim.base)
+	}
+}
+
+func TestAnimatorWidget_RepeatForever(t *testing.T) {
+	a, anim, clock := newTestAnimator(t, 2)
+	a.Driver(DriverFrame).
+		Repeat(RepeatForever).
+		RepeatDelay(time.Second)
+
+	a.Start(PlayPingPong)
+
+	for i := 0; i < 5; i++ {
+		// there and back
+		clock.Advance(time.Second)
+		a.Build()
+		clock.Advance(time.Second)
+		a.Build()
+
+		if !a.IsRunning() {
+			t.Fatalf("animation should be repeated forever (stopped after %d repetitions)", i)
+		}
+
+		if p := a.CurrentPercentageProgress(); p != 0 {
+			t.Errorf("repeated animation should wait for repeat delay: progress %v", p)
+		}
+
+		// repeat delay
+		clock.Advance(time.Second)
+	}
+
+	a.Stop()
+	a.Build()
+
+	if a.IsRunning() {
+		t.Fatal("Stop should stop repeated animation")
+	}
+
+	anim.m.Lock()
+	defer anim.m.Unlock()
+
+	if anim.base != 0 {
+		t.Errorf("stopped animation should stay on key frame 0, got %v", anim.base)
+	}
+}
+
+func TestAnimatorWidget_Callbacks(t *testing.T) {
+	a, _, clock := newTestAnimator(t, 3)
+
+	var events []string
+
+	a.Driver(DriverFrame).
+		OnStart(func() { events = append(events, "start") }).
+		OnKeyFrameReached(func(kf KeyFrame) { events = append(events, "kf"+string(rune('0'+kf))) }).
+		OnFinish(func() { events = append(events, "finish") }).
+		OnCancel(func() { events = append(events, "cancel") })
+
+	a.StartKeyFrames(0, 2, 0, PlayForward)
+
+	for i := 0; i < 2; i++ {
+		clock.Advance(time.Second)
+		a.Build()
+	}
+
+	a.Start(PlayForward)
+	a.Start(PlayForward)
+	a.Stop()
+
+	want := []string{"start", "kf1", "kf2", "finish", "start", "cancel", "start", "cancel"}
+
+	if len(events) != len(want) {
+		t.Fatalf("got events %v, want %v", events, want)
+	}
+
+	for i := range want {
+		if events[i] != want[i] {
+			t.Fatalf("got events %v, want %v", events, want)
+		}
+	}
+}
+
+type timedTestAnimation struct {
+	*testAnimation
+	timings keyFrameTimings
+}
+
+func (t *timedTestAnimation) KeyFrameTiming(kf KeyFrame) KeyFrameTiming {
+	return t.timings[kf]
+}
+
+func TestAnimatorWidget_KeyFrameTiming(t *testing.T) {
+	_, anim, clock := newTestAnimator(t, 3)
+	timed := &timedTestAnimation{anim, make(keyFrameTimings)}
+	timed.timings.setDuration(2, 4*time.Second)
+	timed.timings.setEasingAlgorithm(2, EasingAlgOutQuad)
+
+	a := Animator(timed).
+		ID(giu.ID(t.Name())).
+		Clock(clock).
+		Duration(time.Second).
+		Driver(DriverFrame)
+
+	a.StartKeyFrames(0, 0, 1, PlayForward)
+
+	// 0 -> 1 uses default timing
+	clock.Advance(time.Second / 2)
+	a.Build()
+
+	anim.m.Lock()
+	if !almostEqual(anim.percentage, 0.5) {
+		t.Errorf("0 -> 1: got percentage %v, want 0.5", anim.percentage)
+	}
+	anim.m.Unlock()
+
+	clock.Advance(time.Second / 2)
+	a.Build()
+
+	// 1 -> 2 uses timing of key frame 2
+	clock.Advance(time.Second)
+	a.Build()
+
+	anim.m.Lock()
+	if want := Ease(EasingAlgOutQuad, 0.25); anim.base != 1 || !almostEqual(anim.arbitrary, 0.25) || !almostEqual(anim.percentage, want) {
+		t.Errorf("1 -> 2: got %v%% (eased %v) from %v, want 25%% (eased %v) from 1", anim.arbitrary, anim.percentage, anim.base, want)
+	}
+	anim.m.Unlock()
+
+	// backward 2 -> 1 uses timing of key frame 2 as well
+	a.StartKeyFrames(2, 1, 0, PlayBackward)
+	clock.Advance(time.Second)
+	a.Build()
+
+	anim.m.Lock()
+	defer anim.m.Unlock()
+
+	if anim.base != 2 || !almostEqual(anim.arbitrary, 0.25) {
+		t.Errorf("2 -> 1: got %v%% from %v, want 25%% from 2", anim.arbitrary, anim.base)
+	}
+}
+
+func TestAnimatorWidget_Delay(t *testing.T) {
+	a, anim, clock := newTestAnimator(t, 2)
+	a.Driver(DriverFrame).
+		Delay(500 * time.Millisecond).
+		CycleDelay(time.Second)
+
+	a.StartCycle(2, PlayForward)
+	clock.Advance(300 * time.Millisecond)
+	a.Build()
+
+	anim.m.Lock()
+	if !a.IsRunning() || anim.normalCalls != 1 || anim.animationCalls != 0 {
+		t.Errorf("animation should be built normally while waiting: running %v, %d normal and %d animation calls",
+			a.IsRunning(), anim.normalCalls, anim.animationCalls)
+	}
+	anim.m.Unlock()
+
+	// pause applies to delay
+	a.Pause()
+	clock.Advance(time.Hour)
+	a.Resume()
+
+	clock.Advance(300 * time.Millisecond)
+	a.Build()
+
+	anim.m.Lock()
+	if anim.animationCalls != 1 || !almostEqual(anim.arbitrary, 0.1) {
+		t.Errorf("animation should start after delay: %d animation calls, progress %v", anim.animationCalls, anim.arbitrary)
+	}
+	anim.m.Unlock()
+
+	// finish the first cycle (2 key frames)
+	clock.Advance(900 * time.Millisecond)
+	a.Build()
+	clock.Advance(time.Second)
+	a.Build()
+
+	// the second cycle waits for cycle delay
+	clock.Advance(500 * time.Millisecond)
+	a.Build()
+
+	anim.m.Lock()
+	defer anim.m.Unlock()
+
+	if anim.normalCalls != 3 || anim.base != 0 {
+		t.Errorf("animation should wait for cycle delay on key frame 0: %d normal calls on %v", anim.normalCalls, anim.base)
+	}
+}
+
+func TestAnimatorWidget_ClampKeyFrames(t *testing.T) {
+	a, anim, clock := newTestAnimator(t, 3)
+	a.Driver(DriverFrame).ClampKeyFrames()
+
+	if a.CanAdvance(PlayBackward) || !a.CanAdvance(PlayForward) {
+		t.Errorf("on the first key frame: CanAdvance(PlayBackward) = %v, CanAdvance(PlayForward) = %v",
+			a.CanAdvance(PlayBackward), a.CanAdvance(PlayForward))
+	}
+
+	a.Start(PlayBackward)
+
+	if a.IsRunning() {
+		t.Fatal("Start(PlayBackward) on the first key frame should do nothing")
+	}
+
+	// 1 -> 0 would wrap around, so it stops on 2
+	a.StartKeyFrames(1, 0, 0, PlayForward)
+
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Second)
+		a.Build()
+	}
+
+	if a.IsRunning() {
+		t.Fatal("animation should stop on the last key frame")
+	}
+
+	anim.m.Lock()
+	if anim.base != 2 {
+		t.Errorf("animation should stop on key frame 2, got %v", anim.base)
+	}
+	anim.m.Unlock()
+
+	if a.CanAdvance(PlayForward) || a.CanAdvance(PlayPingPong) || !a.CanAdvance(PlayBackward) {
+		t.Errorf("on the last key frame: CanAdvance(PlayForward) = %v, CanAdvance(PlayBackward) = %v",
+			a.CanAdvance(PlayForward), a.CanAdvance(PlayBackward))
+	}
+
+	a.Start(PlayForward)
+
+	if a.IsRunning() {
+		t.Fatal("Start(PlayForward) on the last key frame should do nothing")
+	}
+}
+
+func TestAnimatorWidget_KeyFramePath(t *testing.T) {
+	a, anim, clock := newTestAnimator(t, 8)
+	a.Driver(DriverFrame).KeyFramePath(KeyFramePathDirect)
+
+	a.StartKeyFrames(1, 7, 0, PlayForward)
+	clock.Advance(time.Second / 2)
+	a.Build()
+
+	anim.m.Lock()
+	if anim.base != 1 || anim.dest != 7 {
+		t.Errorf("direct jump should animate from 1 to 7, got %v -> %v", anim.base, anim.dest)
+	}
+	anim.m.Unlock()
+
+	clock.Advance(time.Second / 2)
+	a.Build()
+
+	if a.IsRunning() {
+		t.Fatal("direct jump should finish after one key frame")
+	}
+
+	anim.m.Lock()
+	if anim.base != 7 {
+		t.Errorf("direct jump should stop on key frame 7, got %v", anim.base)
+	}
+	anim.m.Unlock()
+
+	// 1 -> 7: backward (1, 0, 7) is shorter than forward
+	a.KeyFramePath(KeyFramePathShortest)
+	a.StartKeyFrames(1, 7, 0, PlayForward)
+	clock.Advance(time.Second / 2)
+	a.Build()
+
+	anim.m.Lock()
+	if anim.base != 1 || anim.dest != 0 || anim.mode != PlayBackward {
+		t.Errorf("shortest path from 1 to 7 should go backward, got %v -> %v (%v)", anim.base, anim.dest, anim.mode)
+	}
+	anim.m.Unlock()
+}
+
+func TestAnimatorWidget_SetKeyFrame(t *testing.T) {
+	a, anim, clock := newTestAnimator(t, 4)
+	a.Driver(DriverFrame)
+
+	cancelled := 0
+	a.OnCancel(func() { cancelled++ })
+
+	a.StartKeyFrames(0, 3, 0, PlayForward)
+	clock.Advance(time.Second / 2)
+	a.Build()
+
+	a.SetKeyFrame(2)
+
+	if a.IsRunning() {
+		t.Fatal("SetKeyFrame should stop the animation")
+	}
+
+	if cancelled != 1 {
+		t.Errorf("OnCancel should be called once, got %v", cancelled)
+	}
+
+	if kf := a.CurrentKeyFrame(); kf != 2 {
+		t.Errorf("CurrentKeyFrame() = %v, want 2", kf)
+	}
+
+	a.Build()
+
+	anim.m.Lock()
+	if anim.normalCalls == 0 || anim.base != 2 {
+		t.Errorf("animation should be built normally on key frame 2, got %v", anim.base)
+	}
+	anim.m.Unlock()
+
+	a.Start(PlayForward)
+	clock.Advance(time.Second / 2)
+	a.Build()
+
+	anim.m.Lock()
+	if anim.base != 2 || anim.dest != 3 {
+		t.Errorf("animation should be played from 2 to 3, got %v -> %v", anim.base, anim.dest)
+	}
+	anim.m.Unlock()
+}
+
+func TestAnimatorWidget_DisposeStopsGoroutine(t *testing.T) {
+	before := runtime.NumGoroutine()
+	clock := NewFakeClock(time.Unix(0, 0))
+
+	for i := 0; i < 10; i++ {
+		a, _, _ := newTestAnimator(t, 2)
+		a.ID(giu.ID(t.Name() + string(rune('a'+i)))).Clock(clock).Repeat(RepeatForever)
+		a.Start(PlayForward)
+		a.Build()
+	}
+
+	// all animators share one scheduler goroutine
+	if n := runtime.NumGoroutine(); n != before+1 {
+		t.Fatalf("expected 1 scheduler goroutine, got %v goroutines (%v before)", n, before)
+	}
+
+	// two frames without building the animators: giu disposes their states.
+	for i := 0; i < 2; i++ {
+		giu.Context.SetDirty()
+		giu.Context.GetState(giu.ID(t.Name() + "-other"))
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines leaked: %v running, %v before", runtime.NumGoroutine(), before)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// stateReadingAnimation reads giu state while the animation is advanced.
+type stateReadingAnimation struct {
+	*testAnimation
+	id giu.ID
+}
+
+func (s *stateReadingAnimation) KeyFrameTiming(KeyFrame) KeyFrameTiming {
+	giu.Context.GetState(s.id)
+
+	return KeyFrameTiming{}
+}
+
+func TestAnimatorWidget_DisposeWhileAdvancing(t *testing.T) {
+	_, anim, clock := newTestAnimator(t, 3)
+	a := Animator(&stateReadingAnimation{anim, giu.ID(t.Name() + "-other")}).
+		ID(giu.ID(t.Name())).
+		Clock(clock).
+		Duration(time.Second)
+
+	a.Start(PlayForward)
+	a.Build()
+
+	s := a.getState()
+
+	// the animator is not built in the next frame, so its state gets disposed
+	// on the first GetState call after the frame - here while the animation is advanced.
+	giu.Context.SetDirty()
+	giu.Context.GetState(giu.ID(t.Name() + "-other"))
+	giu.Context.SetDirty()
+
+	clock.Advance(time.Second)
+
+	stopped := make(chan bool)
+
+	go func() {
+		for {
+			s.m.Lock()
+			running := s.isRunning
+			s.m.Unlock()
+
+			if !running {
+				close(stopped)
+
+				return
+			}
+
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("disposed animation did not stop (deadlock?)")
+	}
+}
+
+func TestAnimatorWidget_StartContext(t *testing.T) {
+	a, _, clock := newTestAnimator(t, 4)
+	a.Driver(DriverFrame)
+
+	cancelled := make(chan bool, 1)
+	a.OnCancel(func() { cancelled <- true })
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	a.StartKeyFramesContext(ctx, 0, 3, 0, PlayForward)
+	clock.Advance(time.Second + time.Second/2)
+	a.Build()
+
+	cancel()
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("OnCancel was not called after cancelling the context")
+	}
+
+	if a.IsRunning() {
+		t.Fatal("animation should be stopped after cancelling the context")
+	}
+
+	if kf := a.CurrentKeyFrame(); kf != 1 {
+		t.Errorf("animation should stop on key frame 1, got %v", kf)
+	}
+
+	a.StartContext(ctx, PlayForward)
+
+	if a.IsRunning() {
+		t.Error("StartContext with cancelled context should do nothing")
+	}
+}
+
+func TestAnimatorWidget_DroppedTicks(t *testing.T) {
+	a, _, clock := newTestAnimator(t, 4)
+
+	reached := make(chan KeyFrame, 3)
+	finished := make(chan bool, 1)
+
+	a.OnKeyFrameReached(func(kf KeyFrame) { reached <- kf }).
+		OnFinish(func() { finished <- true })
+
+	a.StartKeyFrames(0, 3, 0, PlayForward)
+
+	// a single tick after the whole duration
+	clock.Advance(3*time.Second + time.Millisecond)
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("animation should finish on the first tick after its duration")
+	}
+
+	for want := KeyFrame(1); want <= 3; want++ {
+		if kf := <-reached; kf != want {
+			t.Errorf("OnKeyFrameReached(%v), want %v", kf, want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package animations
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	_ Clock = systemClock{}
+	_ Clock = &FakeClock{}
+)
+
+// Clock is a source of time used by AnimatorWidget.
+// By default AnimatorWidget uses system clock, however
+// you can replace it (see (*AnimatorWidget).Clock) e.g. with FakeClock
+// in order to control animation's playback manually (in tests for instance).
+type Clock interface {
+	// Now returns current time.
+	Now() time.Time
+	// NewTicker returns a new Ticker that ticks every d.
+	// d is always positive.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker is an abstraction over time.Ticker returned by Clock.
+type Ticker interface {
+	// C returns a channel on which ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// systemClock is a Clock using time package.
+type systemClock struct{}
+
+// Now implements Clock.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTicker implements Clock.
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return &systemTicker{time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+// C implements Ticker.
+func (t *systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// FakeClock is a Clock that does not move unless you call Advance.
+// It is useful for testing animations deterministically.
+// Tickers created by FakeClock behave like time.Ticker: they fire
+// when the clock is advanced past their period and drop ticks
+// if the receiver is not ready.
+type FakeClock struct {
+	now     time.Time
+	tickers []*fakeTicker
+	m       *sync.Mutex
+}
+
+// NewFakeClock creates a new FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now: now,
+		m:   &sync.Mutex{},
+	}
+}
+
+// Now implements Clock.
+func (c *FakeClock) Now() time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.now
+}
+
+// NewTicker implements Clock.
+// Like time.NewTicker, it panics if d is not positive.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for FakeClock.NewTicker")
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	t := &fakeTicker{
+		c:      make(chan time.Time, 1),
+		period: d,
+		next:   c.now.Add(d),
+		clock:  c,
+	}
+
+	c.tickers = append(c.tickers, t)
+
+	return t
+}
+
+// Advance moves the clock forward by d and fires all tickers that should tick in the meantime.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.now = c.now.Add(d)
+
+	for _, t := range c.tickers {
+		if t.next.After(c.now) {
+			continue
+		}
+
+		// skip all missed ticks at once
+		missed := c.now.Sub(t.next)/t.period + 1
+		t.next = t.next.Add(missed * t.period)
+
+		select {
+		case t.c <- c.now:
+		default:
+		}
+	}
+}
+
+func (c *FakeClock) removeTicker(t *fakeTicker) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	for i, ticker := range c.tickers {
+		if ticker == t {
+			c.tickers = append(c.tickers[:i], c.tickers[i+1:]...)
+
+			return
+		}
+	}
+}
+
+type fakeTicker struct {
+	c      chan time.Time
+	period time.Duration
+	next   time.Time
+	clock  *FakeClock
+}
+
+// C implements Ticker.
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+// Stop implements Ticker.
+func (t *fakeTicker) Stop() {
+	t.clock.removeTicker(t)
+}
@@ -1,13 +1,20 @@
 package animations
 
 import (
+	"fmt"
 	"image/color"
+	"time"
 
 	"github.com/AllenDang/cimgui-go/imgui"
 	"github.com/AllenDang/giu"
 )
 
-var _ Animation = &ColorFlowAnimation{}
+var (
+	_ Animation     = &ColorFlowAnimation{}
+	_ KeyFrameTimer = &ColorFlowAnimation{}
+	_ MotionReducer = &ColorFlowAnimation{}
+	_ Validator     = &ColorFlowAnimation{}
+)
 
 // ColorFlowAnimation makes a smooth flow from one color to another
 // on all specified StyleColor variables.
@@ -17,7 +24,8 @@ type ColorFlowAnimation struct {
 	giu.Widget
 	applyingStyles []giu.StyleColorID
 
-	color []func() color.RGBA
+	color   []func() color.RGBA
+	timings keyFrameTimings
 }
 
 // ColorFlowStyle wraps ColorFlow so that it automatically obtains the color for specified style values.
@@ -67,9 +75,35 @@ func ColorFlow(
 		Widget:         widget,
 		applyingStyles: applying,
 		color:          colors,
+		timings:        make(keyFrameTimings),
 	}
 }
 
+// KeyFrameDuration sets duration of flow to the color kf (see KeyFrameTiming).
+func (c *ColorFlowAnimation) KeyFrameDuration(kf KeyFrame, d time.Duration) *ColorFlowAnimation {
+	c.timings.setDuration(kf, d)
+
+	return c
+}
+
+// KeyFrameEasingAlgorithm sets easing algorithm of flow to the color kf (see KeyFrameTiming).
+func (c *ColorFlowAnimation) KeyFrameEasingAlgorithm(kf KeyFrame, alg EasingAlgorithmType) *ColorFlowAnimation {
+	c.timings.setEasingAlgorithm(kf, alg)
+
+	return c
+}
+
+// KeyFrameTiming implements KeyFrameTimer.
+func (c *ColorFlowAnimation) KeyFrameTiming(kf KeyFrame) KeyFrameTiming {
+	return c.timings[kf]
+}
+
+// MotionReduction implements MotionReducer.
+// Color flow does not involve motion, so it is played normally.
+func (c *ColorFlowAnimation) MotionReduction() MotionReduction {
+	return MotionReductionNone
+}
+
 // Reset implements Animation.
 func (c *ColorFlowAnimation) Reset() {
 	// noop
@@ -81,13 +115,28 @@ func (c *ColorFlowAnimation) Init() {
 }
 
 // KeyFramesCount implements Animation.
+// Colors exceeding maximal number of key frames are ignored (see Validate).
 func (c *ColorFlowAnimation) KeyFramesCount() KeyFrame {
-	result := len(c.color)
-	if result > keyFrameMaxSize {
-		panic("Too many KeyFrames")
+	return KeyFrame(min(len(c.color), keyFrameMaxSize))
+}
+
+// Validate implements Validator.
+func (c *ColorFlowAnimation) Validate() error {
+	if c.Widget == nil {
+		return ErrNilWidget
+	}
+
+	if err := validateKeyFramesCount(len(c.color)); err != nil {
+		return err
+	}
+
+	for i, col := range c.color {
+		if col == nil {
+			return fmt.Errorf("color %d: %w", i, ErrNilKeyFrame)
+		}
 	}
 
-	return KeyFrame(result)
+	return nil
 }
 
 // BuildNormal builds animation in normal, not-triggered state.
@@ -0,0 +1,129 @@
+package animations
+
+import "math"
+
+const (
+	// cubicBezierEpsilon is the precision of solving cubic bezier curves.
+	cubicBezierEpsilon = 1e-7
+	// cubicBezierNewtonIterations is the maximal number of Newton's method iterations
+	// before falling back to bisection.
+	cubicBezierNewtonIterations = 8
+)
+
+// CubicBezier returns an EasingAlgorithm described by a cubic bezier curve
+// from (0, 0) to (1, 1) with control points (x1, y1) and (x2, y2) - exactly like
+// CSS cubic-bezier(x1, y1, x2, y2) timing function (e.g. exported from Figma).
+// x1 and x2 must be in range [0, 1] (they are clamped), y1 and y2 may exceed it
+// (what makes the animation overshoot).
+// See also EasingAlgCSSEase and related presets.
+//
+//	Example: animator.EasingFunc(animations.CubicBezier(0.68, -0.6, 0.32, 1.6))
+func CubicBezier(x1, y1, x2, y2 float32) EasingAlgorithm {
+	c := newCubicBezier(
+		min(max(float64(x1), 0), 1), float64(y1),
+		min(max(float64(x2), 0), 1), float64(y2),
+	)
+
+	return c.ease
+}
+
+// cubicBezier holds polynomial coefficients of a cubic bezier curve:
+// x(t) = ((ax*t + bx)*t + cx)*t (and the same for y).
+type cubicBezier struct {
+	ax, bx, cx float64
+	ay, by, cy float64
+}
+
+func newCubicBezier(x1, y1, x2, y2 float64) *cubicBezier {
+	c := &cubicBezier{
+		cx: 3 * x1,
+		cy: 3 * y1,
+	}
+
+	c.bx = 3*(x2-x1) - c.cx
+	c.ax = 1 - c.cx - c.bx
+	c.by = 3*(y2-y1) - c.cy
+	c.ay = 1 - c.cy - c.by
+
+	return c
+}
+
+func (c *cubicBezier) ease(p float32) float32 {
+	switch {
+	case p <= 0:
+		return 0
+	case p >= 1:
+		return 1
+	}
+
+	t := c.solveX(float64(p))
+
+	return float32(((c.ay*t+c.by)*t + c.cy) * t)
+}
+
+func (c *cubicBezier) x(t float64) float64 {
+	return ((c.ax*t+c.bx)*t + c.cx) * t
+}
+
+func (c *cubicBezier) dx(t float64) float64 {
+	return (3*c.ax*t+2*c.bx)*t + c.cx
+}
+
+// solveX finds t for which x(t) = x. x is expected to be in range [0, 1].
+// It uses Newton's method as it converges fast and falls back to bisection
+// (x(t) is monotonic for x1, x2 in range [0, 1]).
+func (c *cubicBezier) solveX(x float64) float64 {
+	t := x
+
+	for i := 0; i < cubicBezierNewtonIterations; i++ {
+		diff := c.x(t) - x
+		if math.Abs(diff) < cubicBezierEpsilon {
+			return t
+		}
+
+		d := c.dx(t)
+		if math.Abs(d) < cubicBezierEpsilon {
+			break
+		}
+
+		t -= diff / d
+	}
+
+	low, high := 0.0, 1.0
+	t = x
+
+	for high-low > cubicBezierEpsilon {
+		diff := c.x(t) - x
+		if math.Abs(diff) < cubicBezierEpsilon {
+			return t
+		}
+
+		if diff > 0 {
+			high = t
+		} else {
+			low = t
+		}
+
+		t = (low + high) / 2
+	}
+
+	return t
+}
+
+// CSS timing functions (see CubicBezier).
+
+func easingAlgCSSEase(p float32) float32 {
+	return CubicBezier(0.25, 0.1, 0.25, 1)(p)
+}
+
+func easingAlgCSSEaseIn(p float32) float32 {
+	return CubicBezier(0.42, 0, 1, 1)(p)
+}
+
+func easingAlgCSSEaseOut(p float32) float32 {
+	return CubicBezier(0, 0, 0.58, 1)(p)
+}
+
+func easingAlgCSSEaseInOut(p float32) float32 {
+	return CubicBezier(0.42, 0, 0.58, 1)(p)
+}
@@ -0,0 +1,17 @@
+package animations
+
+// Driver describes how AnimatorWidget advances a running animation.
+type Driver byte
+
+const (
+	// DriverTicker is the default Driver. The animation is advanced
+	// by a scheduler shared by all animators, ticking FPS times per second.
+	// Every tick requests at most one redraw (giu.Update) for all animations.
+	// Progress does not depend on ticks - it is measured with animator's Clock.
+	DriverTicker Driver = iota
+	// DriverFrame advances the animation in (*AnimatorWidget).Build using
+	// real time elapsed since the previous frame. No goroutine is started;
+	// while the animation is running, Build requests the next frame on its own,
+	// so the animation goes at the pace of the render loop and FPS setting is not used.
+	DriverFrame
+)
@@ -0,0 +1,78 @@
+package animations
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/AllenDang/giu"
+)
+
+// DuplicateIDHandler is called when two different animations are built
+// under the same animator ID in one frame (see SetDuplicateIDHandler).
+// Such animators share their state, what leads to unexpected behaviors.
+type DuplicateIDHandler func(id giu.ID, first, second Animation)
+
+// SetDuplicateIDHandler sets a function called when two different animations are built
+// under the same animator ID in one frame. By default LogDuplicateID is used.
+// nil disables reporting.
+// A conflict is reported once, not in every frame, unless it goes away and comes back.
+func SetDuplicateIDHandler(h DuplicateIDHandler) {
+	registry.m.Lock()
+	defer registry.m.Unlock()
+
+	registry.onDuplicateID = h
+}
+
+// LogDuplicateID is a DuplicateIDHandler that logs the conflict.
+func LogDuplicateID(id giu.ID, first, second Animation) {
+	log.Print(duplicateIDMessage(id, first, second))
+}
+
+// PanicOnDuplicateID is a DuplicateIDHandler that panics.
+// It could be useful in debug builds or tests.
+func PanicOnDuplicateID(id giu.ID, first, second Animation) {
+	panic(duplicateIDMessage(id, first, second))
+}
+
+func duplicateIDMessage(id giu.ID, first, second Animation) string {
+	return fmt.Sprintf(
+		"giu-animations: animator ID %q is used by two animations in one frame: %T and %T; "+
+			"set unique IDs with (*AnimatorWidget).ID",
+		id, first, second,
+	)
+}
+
+// sameAnimation returns true if a and b are the same Animation instance.
+func sameAnimation(a, b Animation) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+
+	return a == b
+}
+
+// duplicateReport is a conflict of two animation types under one ID
+// (see animatorRegistry.shouldReportDuplicate).
+type duplicateReport struct {
+	first, second reflect.Type
+	// frame is the last frame in which the conflict occurred.
+	frame int32
+}
+
+// shouldReportDuplicate records a conflict of first and second under id in frame.
+// It returns false if the same conflict occurred in this or the previous frame,
+// so that every conflict is reported once until it goes away.
+// It must be called with r.m locked.
+func (r *animatorRegistry) shouldReportDuplicate(id giu.ID, frame int32, first, second Animation) bool {
+	report := duplicateReport{
+		first:  reflect.TypeOf(first),
+		second: reflect.TypeOf(second),
+		frame:  frame,
+	}
+
+	prev, ok := r.duplicates[id]
+	r.duplicates[id] = report
+
+	return !ok || prev.first != report.first || prev.second != report.second || prev.frame < frame-1
+}
@@ -1,7 +1,6 @@
 package animations
 
 import (
-	"log"
 	"math"
 )
 
@@ -56,13 +55,43 @@ const (
 	EasingAlgOutBounce
 	EasingAlgInOutBounce
 
+	// CSS timing functions (see CubicBezier)
+	EasingAlgCSSEase
+	EasingAlgCSSEaseIn
+	EasingAlgCSSEaseOut
+	EasingAlgCSSEaseInOut
+
 	EasingAlgMax
 )
 
+// isKnown returns true if e is a valid EasingAlgorithmType.
+func (e EasingAlgorithmType) isKnown() bool {
+	if e < EasingAlgMax {
+		return true
+	}
+
+	_, found := customEasing(e)
+
+	return found
+}
+
+// Func returns e as EasingAlgorithm (e.g. to use it with Reverse, Chain or other combinators).
+func (e EasingAlgorithmType) Func() EasingAlgorithm {
+	return func(p float32) float32 {
+		return Ease(e, p)
+	}
+}
+
 // Ease takes EasingAlgorithmType and plain percentage value t and returns eased value.
 // The following condition is expected to be met, however they are not restricted anyhow:
 // 0 <= t <= 1.
+// Algorithms registered with RegisterEasing are supported as well.
+// Unknown algorithms are treated as EasingAlgNone (see (*AnimatorWidget).Validate).
 func Ease(alg EasingAlgorithmType, t float32) float32 {
+	if custom, found := customEasing(alg); found {
+		return custom(t)
+	}
+
 	algs := map[EasingAlgorithmType]EasingAlgorithm{
 		EasingAlgNone: func(t float32) float32 { return t },
 
@@ -115,11 +144,17 @@ func Ease(alg EasingAlgorithmType, t float32) float32 {
 		EasingAlgInBounce:    easingAlgInBounce,
 		EasingAlgOutBounce:   easingAlgOutBounce,
 		EasingAlgInOutBounce: easingAlgInOutBounce,
+
+		// css
+		EasingAlgCSSEase:      easingAlgCSSEase,
+		EasingAlgCSSEaseIn:    easingAlgCSSEaseIn,
+		EasingAlgCSSEaseOut:   easingAlgCSSEaseOut,
+		EasingAlgCSSEaseInOut: easingAlgCSSEaseInOut,
 	}
 
 	a, found := algs[alg]
 	if !found {
-		log.Panicf("Unknown easing type %v", alg)
+		return t
 	}
 
 	return a(t)
@@ -264,108 +299,41 @@ func easingAlgInOutCirc(p float32) float32 {
 // - back
 
 func easingAlgInBack(p float32) float32 {
-	const s = float32(1.70158)
-
-	return p * p * ((s+1)*p - s)
+	return BackIn(DefaultBackOvershoot)(p)
 }
 
 func easingAlgOutBack(p float32) float32 {
-	const s = float32(1.70158)
-
-	p--
-
-	return p*p*((s+1)*p+s) + 1
+	return BackOut(DefaultBackOvershoot)(p)
 }
 
 func easingAlgInOutBack(p float32) float32 {
-	const s = float32(1.70158) * 1.525
-
-	p *= 2
-
-	if p < 1 {
-		return 0.5 * (p * p * ((s+1)*p - s))
-	}
-
-	p -= 2
-
-	return 0.5 * (p*p*((s+1)*p+s) + 2)
+	return BackInOut(DefaultBackOvershoot)(p)
 }
 
 // - elastic
 
 func easingAlgInElastic(p float32) float32 {
-	switch p {
-	case 0:
-		return 0
-	case 1:
-		return 1
-	default:
-		return -float32(math.Pow(2, 10*float64(p)-10) * math.Sin((float64(p)*10-10.75)*(2*math.Pi)/3))
-	}
+	return ElasticIn(DefaultElasticAmplitude, DefaultElasticPeriod)(p)
 }
 
 func easingAlgOutElastic(p float32) float32 {
-	switch p {
-	case 0:
-		return 0
-	case 1:
-		return 1
-	default:
-		return float32(math.Pow(2, -10*float64(p))*math.Sin((float64(p)*10-0.75)*(2*math.Pi)/3)) + 1
-	}
+	return ElasticOut(DefaultElasticAmplitude, DefaultElasticPeriod)(p)
 }
 
 func easingAlgInOutElastic(p float32) float32 {
-	c5 := (2 * math.Pi) / 4.5
-
-	switch {
-	case p == 0:
-		return 0
-	case p < 0.5:
-		return -float32((math.Pow(2, 20*float64(p)-10) * math.Sin((20*float64(p)-11.125)*c5)) / 2)
-	case p >= 0.5:
-		return float32((math.Pow(2, -20*float64(p)+10)*math.Sin((20*float64(p)-11.125)*c5))/2) + 1
-	case p == 1:
-		return 1
-	}
-
-	return 0
+	return ElasticInOut(DefaultElasticAmplitude, DefaultElasticPeriod)(p)
 }
 
 // - bounce
 
 func easingAlgInBounce(p float32) float32 {
-	return 1 - easingAlgOutBounce(1-p)
+	return BounceIn(DefaultBounceCount, DefaultBounceRestitution)(p)
 }
 
 func easingAlgOutBounce(p float32) float32 {
-	const (
-		n1 = 7.5625
-		d1 = 2.75
-	)
-
-	switch {
-	case p < 1/d1:
-		return n1 * p * p
-	case p < 2/d1:
-		p -= 1.5 / d1
-
-		return n1*p*p + 0.75
-	case p < 2.5/d1:
-		p -= 2.25 / d1
-
-		return n1*p*p + 0.9375
-	}
-
-	p -= 2.625 / d1
-
-	return n1*p*p + 0.984375
+	return BounceOut(DefaultBounceCount, DefaultBounceRestitution)(p)
 }
 
 func easingAlgInOutBounce(p float32) float32 {
-	if p < 0.5 {
-		return easingAlgInBounce(p*2) * 0.5
-	}
-
-	return easingAlgOutBounce(p*2-1)*0.5 + 0.5
+	return BounceInOut(DefaultBounceCount, DefaultBounceRestitution)(p)
 }
@@ -0,0 +1,239 @@
+package animations
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCubicBezier(t *testing.T) {
+	inputs := []float32{0.1, 0.25, 0.5, 0.75, 0.9}
+
+	// reference values computed with high-precision bisection.
+	tests := []struct {
+		name           string
+		preset         EasingAlgorithmType
+		x1, y1, x2, y2 float32
+		want           []float32
+	}{
+		{"ease", EasingAlgCSSEase, 0.25, 0.1, 0.25, 1, []float32{0.094796, 0.408511, 0.802403, 0.960459, 0.994316}},
+		{"ease-in", EasingAlgCSSEaseIn, 0.42, 0, 1, 1, []float32{0.017027, 0.093465, 0.315357, 0.621862, 0.839428}},
+		{"ease-out", EasingAlgCSSEaseOut, 0, 0, 0.58, 1, []float32{0.160572, 0.378138, 0.684643, 0.906535, 0.982973}},
+		{"ease-in-out", EasingAlgCSSEaseInOut, 0.42, 0, 0.58, 1, []float32{0.019722, 0.129162, 0.5, 0.870838, 0.980278}},
+		{"overshoot", EasingAlgNone, 0.68, -0.6, 0.32, 1.6, []float32{-0.072823, -0.097708, 0.5, 1.097708, 1.072823}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			curve := CubicBezier(tt.x1, tt.y1, tt.x2, tt.y2)
+
+			for i, x := range inputs {
+				if got := curve(x); math.Abs(float64(got-tt.want[i])) > 1e-5 {
+					t.Errorf("CubicBezier(%v, %v, %v, %v)(%v) = %v, want %v", tt.x1, tt.y1, tt.x2, tt.y2, x, got, tt.want[i])
+				}
+
+				if tt.preset == EasingAlgNone {
+					continue
+				}
+
+				if got := Ease(tt.preset, x); math.Abs(float64(got-tt.want[i])) > 1e-5 {
+					t.Errorf("preset %s(%v) = %v, want %v", tt.name, x, got, tt.want[i])
+				}
+			}
+
+			if curve(0) != 0 || curve(1) != 1 {
+				t.Errorf("curve should start at 0 and end at 1, got %v and %v", curve(0), curve(1))
+			}
+		})
+	}
+}
+
+func TestSteps(t *testing.T) {
+	inputs := []float32{0, 0.1, 0.3, 0.5, 0.8, 1}
+
+	tests := []struct {
+		name string
+		n    int
+		jump StepJump
+		want []float32
+	}{
+		{"jump-end", 4, StepJumpEnd, []float32{0, 0, 0.25, 0.5, 0.75, 1}},
+		{"jump-start", 4, StepJumpStart, []float32{0.25, 0.25, 0.5, 0.75, 1, 1}},
+		{"jump-both", 4, StepJumpBoth, []float32{0.2, 0.2, 0.4, 0.6, 0.8, 1}},
+		{"jump-none", 4, StepJumpNone, []float32{0, 0, 1.0 / 3, 2.0 / 3, 1, 1}},
+		{"single step", 0, StepJumpEnd, []float32{0, 0, 0, 0, 0, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := Steps(tt.n, tt.jump)
+
+			for i, x := range inputs {
+				if got := steps(x); !almostEqual(got, tt.want[i]) {
+					t.Errorf("Steps(%v, %v)(%v) = %v, want %v", tt.n, tt.jump, x, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLinear(t *testing.T) {
+	tests := []struct {
+		name   string
+		easing EasingAlgorithm
+		inputs []float32
+		want   []float32
+	}{
+		{"no stops", Linear(), []float32{0, 0.3, 1}, []float32{0, 0.3, 1}},
+		{"one stop", Linear(0.5), []float32{0, 0.3, 1}, []float32{0.5, 0.5, 0.5}},
+		{"even stops", Linear(0, 1.2, 0.9, 1), []float32{0, 1.0 / 6, 0.5, 0.75, 1}, []float32{0, 0.6, 1.05, 0.925, 1}},
+		{
+			"stop positions",
+			LinearStops(LinearStop{0, 0}, LinearStop{0.8, 1.1}, LinearStop{1, 1}),
+			[]float32{0.4, 0.8, 0.9},
+			[]float32{0.55, 1.1, 1.05},
+		},
+		{
+			"jump",
+			LinearStops(LinearStop{0, 0}, LinearStop{0.5, 0}, LinearStop{0.2, 1}, LinearStop{1, 1}),
+			[]float32{0.4, 0.5, 0.7},
+			[]float32{0, 1, 1},
+		},
+		{"extrapolation", Linear(0, 0.5), []float32{-0.5, 1.5}, []float32{-0.25, 0.75}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, x := range tt.inputs {
+				if got := tt.easing(x); !almostEqual(got, tt.want[i]) {
+					t.Errorf("(%v) = %v, want %v", x, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEase_Defaults(t *testing.T) {
+	inputs := []float32{0.1, 0.3, 0.5, 0.7, 0.9, 1}
+
+	// reference values of built-in algorithms (see Back*, Elastic* and Bounce* constructors).
+	tests := []struct {
+		alg  EasingAlgorithmType
+		want []float32
+	}{
+		{EasingAlgInBack, []float32{-0.014314, -0.080200, -0.087698, 0.092868, 0.591172, 1}},
+		{EasingAlgOutBack, []float32{0.408828, 0.907132, 1.087698, 1.080200, 1.014314, 1}},
+		{EasingAlgInOutBack, []float32{-0.037519, -0.078833, 0.5, 1.078833, 1.037519, 1}},
+		{EasingAlgInElastic, []float32{0.001953, -0.003906, -0.015625, 0.125000, -0.25, 1}},
+		{EasingAlgOutElastic, []float32{1.25, 0.875, 1.015625, 1.003906, 0.998047, 1}},
+		{EasingAlgInOutElastic, []float32{0.000339, 0.023939, 0.5, 0.976061, 0.999661, 1}},
+		{EasingAlgInBounce, []float32{0.011875, 0.069375, 0.234375, 0.319375, 0.924375, 1}},
+		{EasingAlgOutBounce, []float32{0.075625, 0.680625, 0.765625, 0.930625, 0.988125, 1}},
+		{EasingAlgInOutBounce, []float32{0.03, 0.045, 0.5, 0.955, 0.97, 1}},
+	}
+
+	for _, tt := range tests {
+		for i, x := range inputs {
+			if got := Ease(tt.alg, x); math.Abs(float64(got-tt.want[i])) > 1e-5 {
+				t.Errorf("Ease(%v, %v) = %v, want %v", tt.alg, x, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestBackElasticBounce(t *testing.T) {
+	// extremes returns the lowest and the highest value of e.
+	extremes := func(e EasingAlgorithm) (lowest, highest float32) {
+		for x := float32(0); x <= 1; x += 0.001 {
+			lowest, highest = min(lowest, e(x)), max(highest, e(x))
+		}
+
+		return lowest, highest
+	}
+
+	gentle, _ := extremes(BackIn(0.5))
+	strong, _ := extremes(BackIn(3))
+
+	if gentle <= strong || gentle >= 0 {
+		t.Errorf("bigger overshoot should go further back: BackIn(0.5) reaches %v, BackIn(3) reaches %v", gentle, strong)
+	}
+
+	if got := BackOut(0)(0.5); !almostEqual(got, 0.875) {
+		t.Errorf("BackOut with no overshoot should be cubic: BackOut(0)(0.5) = %v, want 0.875", got)
+	}
+
+	_, weak := extremes(ElasticOut(1, 0.3))
+	_, strong = extremes(ElasticOut(2, 0.3))
+
+	if strong <= weak {
+		t.Errorf("bigger amplitude should oscillate further: ElasticOut(2, 0.3) reaches %v, ElasticOut(1, 0.3) reaches %v", strong, weak)
+	}
+
+	// with period 0.4 the first maximum of ElasticOut is at 0.2.
+	if got := ElasticOut(1, 0.4)(0.2); !almostEqual(got, 1.25) {
+		t.Errorf("ElasticOut(1, 0.4)(0.2) = %v, want 1.25", got)
+	}
+
+	// one bounce keeping half of speed: fall takes 1/2 of time and reaches 1 at 0.5.
+	bounce := BounceOut(1, 0.5)
+
+	for x, want := range map[float32]float32{0.25: 0.25, 0.5: 1, 0.75: 0.75, 1: 1} {
+		if got := bounce(x); !almostEqual(got, want) {
+			t.Errorf("BounceOut(1, 0.5)(%v) = %v, want %v", x, got, want)
+		}
+	}
+
+	if got := BounceOut(0, 0.5)(0.5); !almostEqual(got, 0.25) {
+		t.Errorf("BounceOut with no bounces should be quadratic: BounceOut(0, 0.5)(0.5) = %v, want 0.25", got)
+	}
+
+	for _, e := range []EasingAlgorithm{BackInOut(3), ElasticInOut(2, 0.5), BounceInOut(5, 0.3), BounceIn(2, 0.7)} {
+		if e(0) != 0 || !almostEqual(e(1), 1) {
+			t.Errorf("easing should start at 0 and end at 1, got %v and %v", e(0), e(1))
+		}
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	inQuad, outQuad, inOutQuad := EasingAlgInQuad.Func(), EasingAlgOutQuad.Func(), EasingAlgInOutQuad.Func()
+	linear := EasingAlgNone.Func()
+	inputs := []float32{0, 0.1, 0.3, 0.5, 0.7, 0.9, 1}
+
+	tests := []struct {
+		name   string
+		easing EasingAlgorithm
+		want   EasingAlgorithm
+	}{
+		{"reverse", Reverse(inQuad), outQuad},
+		{"reverse twice", Reverse(Reverse(inQuad)), inQuad},
+		{"mirror", Mirror(inQuad), inOutQuad},
+		{"chain", Chain(linear, linear, 0.3), linear},
+		{"chain at 0", Chain(inQuad, outQuad, 0), outQuad},
+		{"chain at 1", Chain(inQuad, outQuad, 1), inQuad},
+		{"blend", Blend(inQuad, outQuad, 0.5), func(p float32) float32 { return (inQuad(p) + outQuad(p)) / 2 }},
+		{"blend with 0", Blend(inQuad, outQuad, 0), inQuad},
+		{"clamp", Clamp(func(p float32) float32 { return 2*p - 0.5 }), func(p float32) float32 { return min(max(2*p-0.5, 0), 1) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, x := range inputs {
+				if got, want := tt.easing(x), tt.want(x); !almostEqual(got, want) {
+					t.Errorf("(%v) = %v, want %v", x, got, want)
+				}
+			}
+		})
+	}
+
+	// ease-in for the first half and bounce for the second one.
+	chain := Chain(inQuad, BounceOut(DefaultBounceCount, DefaultBounceRestitution), 0.5)
+
+	for x, want := range map[float32]float32{0.25: 0.125, 0.5: 0.5, 0.75: 0.5 + Ease(EasingAlgOutBounce, 0.5)/2, 1: 1} {
+		if got := chain(x); !almostEqual(got, want) {
+			t.Errorf("Chain(in quad, bounce, 0.5)(%v) = %v, want %v", x, got, want)
+		}
+	}
+
+	if got := Clamp(BackOut(DefaultBackOvershoot))(0.5); got != 1 {
+		t.Errorf("Clamp should cut off overshooting, got %v", got)
+	}
+}
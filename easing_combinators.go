@@ -0,0 +1,62 @@
+package animations
+
+// Reverse returns the time-reversed e: an "in" easing becomes an "out" one and vice versa
+// (e.g. Reverse of EasingAlgInQuad is EasingAlgOutQuad). It still goes from 0 to 1.
+func Reverse(e EasingAlgorithm) EasingAlgorithm {
+	return func(p float32) float32 {
+		return 1 - e(1-p)
+	}
+}
+
+// Mirror returns symmetric "in-out" version of e: e is played for the first half
+// and Reverse(e) for the second one (e.g. Mirror of EasingAlgInQuad is EasingAlgInOutQuad).
+func Mirror(e EasingAlgorithm) EasingAlgorithm {
+	return func(p float32) float32 {
+		return inOut(p, e)
+	}
+}
+
+// Chain joins two easings: e1 is played until split (in range [0, 1])
+// and e2 for the rest of the key frame. Both are scaled so that the curve is continuous
+// (the value at split is split).
+//
+//	Example: animations.Chain(animations.EasingAlgInQuad.Func(), animations.BounceOut(3, 0.5), 0.5)
+func Chain(e1, e2 EasingAlgorithm, split float32) EasingAlgorithm {
+	split = min(max(split, 0), 1)
+
+	return func(p float32) float32 {
+		switch {
+		case split == 0:
+			return e2(p)
+		case p < split || split == 1:
+			return e1(p/split) * split
+		}
+
+		return split + e2((p-split)/(1-split))*(1-split)
+	}
+}
+
+// Blend mixes two easings: w = 0 gives e1, w = 1 gives e2
+// and values between make a weighted average.
+func Blend(e1, e2 EasingAlgorithm, w float32) EasingAlgorithm {
+	return func(p float32) float32 {
+		return (1-w)*e1(p) + w*e2(p)
+	}
+}
+
+// Clamp keeps values of e in range [0, 1] (e.g. to cut off overshooting
+// of Back or Elastic easings).
+func Clamp(e EasingAlgorithm) EasingAlgorithm {
+	return func(p float32) float32 {
+		return min(max(e(p), 0), 1)
+	}
+}
+
+// inOut plays in for the first half of p and in backwards for the second one.
+func inOut(p float32, in EasingAlgorithm) float32 {
+	if p < 0.5 {
+		return in(p*2) / 2
+	}
+
+	return 1 - in(2-p*2)/2
+}
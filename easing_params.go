@@ -0,0 +1,136 @@
+package animations
+
+import "math"
+
+// Default parameters of Back, Elastic and Bounce easings.
+// They are used by EasingAlgInBack, EasingAlgInElastic, EasingAlgInBounce and related algorithms.
+const (
+	DefaultBackOvershoot     = 1.70158
+	DefaultElasticAmplitude  = 1
+	DefaultElasticPeriod     = 0.3
+	DefaultBounceCount       = 3
+	DefaultBounceRestitution = 0.5
+)
+
+// backInOutFactor scales overshoot in BackInOut so that it looks like In and Out variants.
+const backInOutFactor = 1.525
+
+// elasticInOutFactor scales period in ElasticInOut so that it looks like In and Out variants.
+const elasticInOutFactor = 1.5
+
+// BackIn returns an EasingAlgorithm going slightly backwards before moving forward
+// (like EasingAlgInBack). overshoot tells how far it goes back (DefaultBackOvershoot
+// makes it go back by 10%). Use smaller values for a gentle effect and bigger for a strong one.
+func BackIn(overshoot float32) EasingAlgorithm {
+	return func(p float32) float32 {
+		return backIn(overshoot, p)
+	}
+}
+
+// BackOut returns an EasingAlgorithm overshooting the destination before coming back
+// to it (like EasingAlgOutBack). See BackIn.
+func BackOut(overshoot float32) EasingAlgorithm {
+	return Reverse(BackIn(overshoot))
+}
+
+// BackInOut combines BackIn and BackOut (like EasingAlgInOutBack). See BackIn.
+func BackInOut(overshoot float32) EasingAlgorithm {
+	return Mirror(BackIn(overshoot * backInOutFactor))
+}
+
+func backIn(s, p float32) float32 {
+	return p * p * ((s+1)*p - s)
+}
+
+// ElasticIn returns an EasingAlgorithm oscillating with growing amplitude
+// before reaching the destination (like EasingAlgInElastic).
+// amplitude (at least 1) tells how far the oscillations reach
+// and period (a fraction of the key frame's duration) tells how long one oscillation takes.
+// See DefaultElasticAmplitude and DefaultElasticPeriod.
+func ElasticIn(amplitude, period float32) EasingAlgorithm {
+	a, s, omega := elasticParams(amplitude, period)
+
+	return func(p float32) float32 {
+		return elasticIn(a, s, omega, p)
+	}
+}
+
+// ElasticOut returns an EasingAlgorithm oscillating around the destination
+// with fading amplitude (like EasingAlgOutElastic). See ElasticIn.
+func ElasticOut(amplitude, period float32) EasingAlgorithm {
+	return Reverse(ElasticIn(amplitude, period))
+}
+
+// ElasticInOut combines ElasticIn and ElasticOut (like EasingAlgInOutElastic). See ElasticIn.
+func ElasticInOut(amplitude, period float32) EasingAlgorithm {
+	return Mirror(ElasticIn(amplitude, period*elasticInOutFactor))
+}
+
+// elasticParams returns amplitude, phase shift and angular frequency of an elastic easing.
+func elasticParams(amplitude, period float32) (a, s, omega float64) {
+	a, t := math.Max(float64(amplitude), 1), float64(period)
+	if t <= 0 {
+		t = DefaultElasticPeriod
+	}
+
+	return a, t / (2 * math.Pi) * math.Asin(1/a), 2 * math.Pi / t
+}
+
+func elasticIn(a, s, omega float64, p float32) float32 {
+	switch p {
+	case 0:
+		return 0
+	case 1:
+		return 1
+	}
+
+	t := float64(p) - 1
+
+	return -float32(a * math.Pow(2, 10*t) * math.Sin((t-s)*omega))
+}
+
+// BounceOut returns an EasingAlgorithm falling to the destination and bouncing off it
+// (like EasingAlgOutBounce). count is the number of bounces and restitution (between 0 and 1)
+// tells which part of its speed the bounce keeps (so every bounce is restitution^2 times
+// lower than the previous one). See DefaultBounceCount and DefaultBounceRestitution.
+func BounceOut(count int, restitution float32) EasingAlgorithm {
+	count, r := max(count, 0), math.Max(float64(restitution), 0)
+
+	// duration of the fall is 1, every bounce takes twice its speed.
+	total := 1.0
+	for i := 1; i <= count; i++ {
+		total += 2 * math.Pow(r, float64(i))
+	}
+
+	return func(p float32) float32 {
+		t := float64(p) * total
+		if t < 1 {
+			return float32(t * t)
+		}
+
+		start := 1.0
+
+		for i := 1; i <= count; i++ {
+			halfWidth := math.Pow(r, float64(i))
+			if t < start+2*halfWidth || i == count {
+				center := start + halfWidth
+
+				return float32((t-center)*(t-center) + 1 - halfWidth*halfWidth)
+			}
+
+			start += 2 * halfWidth
+		}
+
+		return 1
+	}
+}
+
+// BounceIn returns BounceOut played backwards (like EasingAlgInBounce). See BounceOut.
+func BounceIn(count int, restitution float32) EasingAlgorithm {
+	return Reverse(BounceOut(count, restitution))
+}
+
+// BounceInOut combines BounceIn and BounceOut (like EasingAlgInOutBounce). See BounceOut.
+func BounceInOut(count int, restitution float32) EasingAlgorithm {
+	return Mirror(BounceIn(count, restitution))
+}
@@ -0,0 +1,69 @@
+package animations
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// ErrTooManyEasings is reported by RegisterEasing when there is no free EasingAlgorithmType left.
+var ErrTooManyEasings = errors.New("too many custom easing algorithms")
+
+// easingRegistry holds easing algorithms registered with RegisterEasing (see customEasings).
+// funcs[i] is the algorithm of type EasingAlgMax+i.
+type easingRegistry struct {
+	names map[string]EasingAlgorithmType
+	funcs []EasingAlgorithm
+	m     *sync.RWMutex
+}
+
+// RegisterEasing registers a custom easing algorithm under the given name
+// and returns a new EasingAlgorithmType for it, so that it could be used wherever
+// a built-in one is (e.g. (*AnimatorWidget).EasingAlgorithm, KeyFrameTiming or Ease).
+// Registering a name again replaces its function and returns the same type.
+// Only a limited number of algorithms could be registered: on overflow ErrTooManyEasings
+// is passed to the error handler (see SetErrorHandler) and EasingAlgNone is returned.
+//
+// Register your algorithms once (e.g. in init or package-level variables), not in the loop:
+//
+//	var EasingAlgSpring = animations.RegisterEasing("spring", mySpring)
+func RegisterEasing(name string, fn EasingAlgorithm) EasingAlgorithmType {
+	customEasings.m.Lock()
+	defer customEasings.m.Unlock()
+
+	if alg, ok := customEasings.names[name]; ok {
+		customEasings.funcs[alg-EasingAlgMax] = fn
+
+		return alg
+	}
+
+	if len(customEasings.funcs) > math.MaxUint8-int(EasingAlgMax) {
+		reportError(fmt.Errorf("%w: cannot register %q", ErrTooManyEasings, name))
+
+		return EasingAlgNone
+	}
+
+	alg := EasingAlgMax + EasingAlgorithmType(len(customEasings.funcs))
+	customEasings.funcs = append(customEasings.funcs, fn)
+	customEasings.names[name] = alg
+
+	return alg
+}
+
+// customEasing returns algorithm registered as alg (see RegisterEasing).
+func customEasing(alg EasingAlgorithmType) (fn EasingAlgorithm, found bool) {
+	if alg < EasingAlgMax {
+		return nil, false
+	}
+
+	customEasings.m.RLock()
+	defer customEasings.m.RUnlock()
+
+	i := int(alg - EasingAlgMax)
+	if i >= len(customEasings.funcs) || customEasings.funcs[i] == nil {
+		return nil, false
+	}
+
+	return customEasings.funcs[i], true
+}
@@ -0,0 +1,102 @@
+package animations
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// resetCustomEasings restores the easing registry after the test.
+func resetCustomEasings(t *testing.T) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		customEasings.m.Lock()
+		defer customEasings.m.Unlock()
+
+		customEasings.names = make(map[string]EasingAlgorithmType)
+		customEasings.funcs = nil
+	})
+}
+
+func TestRegisterEasing(t *testing.T) {
+	resetCustomEasings(t)
+
+	square := RegisterEasing("square", func(p float32) float32 { return p * p })
+	half := RegisterEasing("half", func(p float32) float32 { return p / 2 })
+
+	if square != EasingAlgMax || half != EasingAlgMax+1 {
+		t.Fatalf("RegisterEasing should return consecutive types after EasingAlgMax; got %v and %v", square, half)
+	}
+
+	if got := Ease(square, 0.5); !almostEqual(got, 0.25) {
+		t.Errorf("Ease(square, 0.5) = %v, want 0.25", got)
+	}
+
+	if again := RegisterEasing("square", func(p float32) float32 { return p * p * p }); again != square {
+		t.Errorf("registering a name again should return the same type: got %v, want %v", again, square)
+	}
+
+	if got := Ease(square, 0.5); !almostEqual(got, 0.125) {
+		t.Errorf("registering a name again should replace its function: Ease(square, 0.5) = %v, want 0.125", got)
+	}
+
+	a, _, _ := newTestAnimator(t, 2)
+	a.EasingAlgorithm(half)
+
+	if err := a.Validate(); err != nil {
+		t.Errorf("registered easing should be valid: %v", err)
+	}
+
+	a.EasingAlgorithm(half + 1)
+
+	if err := a.Validate(); !errors.Is(err, ErrUnknownEasing) {
+		t.Errorf("unregistered easing should be invalid, got %v", err)
+	}
+}
+
+func TestRegisterEasing_Overflow(t *testing.T) {
+	resetCustomEasings(t)
+
+	var reported error
+
+	SetErrorHandler(func(err error) { reported = err })
+	t.Cleanup(func() { SetErrorHandler(LogError) })
+
+	identity := func(p float32) float32 { return p }
+
+	for i := int(EasingAlgMax); i <= 255; i++ {
+		RegisterEasing(string(rune(i)), identity)
+	}
+
+	if reported != nil {
+		t.Fatalf("no error expected before the overflow, got %v", reported)
+	}
+
+	if alg := RegisterEasing("one too many", identity); alg != EasingAlgNone {
+		t.Errorf("RegisterEasing should return EasingAlgNone on overflow, got %v", alg)
+	}
+
+	if !errors.Is(reported, ErrTooManyEasings) {
+		t.Errorf("expected ErrTooManyEasings, got %v", reported)
+	}
+}
+
+func TestAnimatorWidget_EasingFunc(t *testing.T) {
+	a, anim, clock := newTestAnimator(t, 2)
+	a.Driver(DriverFrame).
+		EasingAlgorithm(EasingAlgInQuad).
+		EasingFunc(func(p float32) float32 { return 1 - p })
+
+	a.Start(PlayForward)
+	clock.Advance(200 * time.Millisecond)
+	a.Build()
+
+	anim.m.Lock()
+	defer anim.m.Unlock()
+
+	if anim.animationCalls != 1 || !almostEqual(anim.percentage, 0.8) {
+		t.Errorf("EasingFunc should override EasingAlgorithm: %v BuildAnimation calls, percentage %v, want 0.8",
+			anim.animationCalls, anim.percentage)
+	}
+}
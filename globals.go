@@ -0,0 +1,55 @@
+package animations
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Package-level state.
+//
+// giu creates widgets every frame and keeps their state per ID, so everything
+// that must be shared by all animators (or set up by the application once,
+// not per widget) lives here. Each variable guards itself with its own lock.
+//
+//nolint:gochecknoglobals // see the comment of every variable
+var (
+	// sharedScheduler advances all DriverTicker animations: one goroutine per Clock and FPS
+	// instead of one per animator (see scheduler).
+	sharedScheduler = newScheduler()
+
+	// registry tracks AnimatorWidgets being built. Animators are created every frame,
+	// so only a package-level registry could list them (see Animators and SetDuplicateIDHandler).
+	registry = newAnimatorRegistry()
+
+	// frameCount returns number of the current frame (see registry).
+	// It is a variable, so that tests could replace it.
+	frameCount = imgui.FrameCount
+
+	// globalTime holds settings of TimeScale, PauseAll and ResumeAll
+	// which affect all animations at once.
+	globalTime = &timeSettings{
+		scale: 1,
+		m:     &sync.RWMutex{},
+	}
+
+	// reducedMotion tells whether reduced motion is enabled (see SetReducedMotion).
+	// It is a preference of the user, not of a particular animation.
+	reducedMotion atomic.Bool
+
+	// errorHandler is called with errors of all animators (see SetErrorHandler),
+	// because errors occur also where the application has no animator at hand (e.g. in Build).
+	errorHandler = &errorHandlerSettings{
+		f: LogError,
+		m: &sync.Mutex{},
+	}
+
+	// customEasings holds easing algorithms registered with RegisterEasing.
+	// EasingAlgorithmType is used everywhere as a plain value, so its functions
+	// must be resolved without any animator (see Ease).
+	customEasings = &easingRegistry{
+		names: make(map[string]EasingAlgorithmType),
+		m:     &sync.RWMutex{},
+	}
+)
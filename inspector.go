@@ -0,0 +1,77 @@
+package animations
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/giu"
+)
+
+var _ giu.Widget = &InspectorWidget{}
+
+// InspectorWidget is a developer tool listing all AnimatorWidgets being built (see Animators).
+// It shows their state and live progress and allows to pause, scrub or replay them.
+//
+//	Example: giu.Window("Animations").Layout(animations.Inspector())
+type InspectorWidget struct{}
+
+// Inspector creates a new InspectorWidget.
+func Inspector() *InspectorWidget {
+	return &InspectorWidget{}
+}
+
+// Build implements giu.Widget.
+func (i *InspectorWidget) Build() {
+	animators := Animators()
+
+	if len(animators) == 0 {
+		giu.Label("No animators are being built.").Build()
+
+		return
+	}
+
+	running := false
+
+	for _, a := range animators {
+		info := a.Info()
+		running = running || info.IsRunning
+
+		i.buildAnimator(a, info)
+	}
+
+	// keep progress bars alive
+	if running {
+		giu.Update()
+	}
+}
+
+func (i *InspectorWidget) buildAnimator(a *AnimatorWidget, info AnimatorInfo) {
+	status := "idle"
+
+	switch {
+	case info.IsPaused:
+		status = "paused"
+	case info.IsRunning:
+		status = "running"
+	}
+
+	pauseLabel, pause := "Pause", a.Pause
+	if info.IsPaused {
+		pauseLabel, pause = "Resume", a.Resume
+	}
+
+	progress := info.Progress
+
+	giu.TreeNode(fmt.Sprintf("%s (%s) - %s##%s", info.ID, info.AnimationType, status, info.ID)).Layout(
+		giu.Labelf("Key frame: %d -> %d (of %d)", info.CurrentKeyFrame, info.DestinationKeyFrame, info.KeyFramesCount),
+		giu.Labelf("Easing: %v, duration: %v, FPS: %d", info.EasingAlgorithm, info.Duration, info.FPS),
+		giu.ProgressBar(info.Progress).Size(-1, 0),
+		giu.SliderFloat(&progress, 0, 1).Label("Scrub##"+string(info.ID)).OnChange(func() {
+			a.Seek(progress)
+		}),
+		giu.Row(
+			giu.Button(pauseLabel+"##"+string(info.ID)).Disabled(!info.IsRunning).OnClick(pause),
+			giu.Button("Replay##"+string(info.ID)).OnClick(a.Replay),
+			giu.Button("Stop##"+string(info.ID)).Disabled(!info.IsRunning).OnClick(a.Stop),
+		),
+	).Build()
+}
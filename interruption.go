@@ -0,0 +1,23 @@
+package animations
+
+// InterruptionPolicy tells AnimatorWidget what to do when any of Start* methods
+// is called while the animation is running.
+type InterruptionPolicy byte
+
+const (
+	// InterruptRestart is the default policy. Running animation is stopped
+	// and the new one starts from the current key frame.
+	InterruptRestart InterruptionPolicy = iota
+	// InterruptReverse smoothly reverses running animation if it is
+	// started so that it would go back to the key frame it started from
+	// (Start in opposite direction, or - if the animation has 2 key frames - in any direction).
+	// Playback continues from the current (eased) position back to that key frame
+	// and takes only the remaining time. Other Start* calls are ignored.
+	// With this policy TriggerOnChange is checked also while the animation is running,
+	// so that e.g. hover animation reverses as soon as the item gets un-hovered.
+	InterruptReverse
+	// InterruptIgnore ignores all Start* calls while the animation is running.
+	InterruptIgnore
+	// InterruptQueue postpones Start* calls until running animation finishes.
+	InterruptQueue
+)
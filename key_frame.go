@@ -1,7 +1,6 @@
 package animations
 
 import (
-	"log"
 	"math"
 )
 
@@ -12,27 +11,17 @@ type KeyFrame int16
 
 const keyFrameMaxSize = math.MaxInt16
 
-// getWithDelta returns clamp(0, count-1, current + delta)
-// it can return a numbers of cycles to be performed
-// TODO(gucio321): I believe it could be done simpler.
+// getWithDelta returns current + delta wrapped around to the range [0, count).
+// If there are no key frames (count <= 0), it returns 0.
 func getWithDelta(current, count, delta KeyFrame) KeyFrame {
-	// special case
-	if count == 1 && (delta == 1 || delta == -1) {
+	if count <= 0 {
 		return 0
 	}
 
-	if delta >= count {
-		log.Panicf("Unexpected delta received: %v expected at most %v", delta, count-1)
-	}
-
-	result := current + delta
+	result := (int(current) + int(delta)) % int(count)
 	if result < 0 {
-		result = count + result
-	}
-
-	if result >= count {
-		result -= count
+		result += int(count)
 	}
 
-	return result
+	return KeyFrame(result)
 }
@@ -0,0 +1,18 @@
+package animations
+
+// KeyFramePath tells AnimatorWidget how to get from one key frame to another
+// in (*AnimatorWidget).StartKeyFrames.
+type KeyFramePath byte
+
+const (
+	// KeyFramePathSequential is the default path. Animation goes through all
+	// key frames between the beginning and the destination (in direction of play mode).
+	KeyFramePathSequential KeyFramePath = iota
+	// KeyFramePathDirect animates straight from the beginning to the destination key frame
+	// in one segment (e.g. from page 1 to page 7 of a transition without showing pages in between).
+	// Number of cycles is ignored for direct jumps.
+	KeyFramePathDirect
+	// KeyFramePathShortest goes through key frames like KeyFramePathSequential, but
+	// the direction (play mode) is chosen automatically so that the path is as short as possible.
+	KeyFramePathShortest
+)
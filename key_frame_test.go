@@ -21,6 +21,8 @@ func Test_getWithDelta(t *testing.T) {
 		{"previous 1<-0", args{0, 2, -1}, 1},
 		{"previous 2<-0", args{0, 3, -1}, 2},
 		{"Only one frame", args{0, 1, 1}, 0},
+		{"delta greater than count 0->2", args{0, 3, 5}, 2},
+		{"no key frames", args{0, 0, 1}, 0},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
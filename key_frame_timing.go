@@ -0,0 +1,37 @@
+package animations
+
+import "time"
+
+// KeyFrameTiming allows to override AnimatorWidget's duration and easing algorithm
+// for a particular key frame.
+// Timing of a key frame applies to the transition between this key frame
+// and the previous one (in both directions).
+type KeyFrameTiming struct {
+	// Duration of the transition. 0 means AnimatorWidget's duration.
+	Duration time.Duration
+	// EasingAlgorithm of the transition. It is used only if OverrideEasing is set.
+	EasingAlgorithm EasingAlgorithmType
+	OverrideEasing  bool
+}
+
+// KeyFrameTimer could be implemented by an Animation in order to
+// specify KeyFrameTiming of its key frames.
+type KeyFrameTimer interface {
+	KeyFrameTiming(kf KeyFrame) KeyFrameTiming
+}
+
+// keyFrameTimings is a helper for animations implementing KeyFrameTimer.
+type keyFrameTimings map[KeyFrame]KeyFrameTiming
+
+func (k keyFrameTimings) setDuration(kf KeyFrame, d time.Duration) {
+	t := k[kf]
+	t.Duration = d
+	k[kf] = t
+}
+
+func (k keyFrameTimings) setEasingAlgorithm(kf KeyFrame, alg EasingAlgorithmType) {
+	t := k[kf]
+	t.EasingAlgorithm = alg
+	t.OverrideEasing = true
+	k[kf] = t
+}
@@ -0,0 +1,65 @@
+package animations
+
+// LinearStop is a point of Linear easing: at Input progress the eased progress is Output.
+type LinearStop struct {
+	Input  float32
+	Output float32
+}
+
+// Linear returns a piecewise-linear EasingAlgorithm going through outputs
+// spaced evenly between 0 and 1 - like CSS linear() timing function with no stop positions.
+// It is useful to approximate complex curves (e.g. springs exported from design tools).
+// See LinearStops to specify positions of stops.
+//
+//	Example: animator.EasingFunc(animations.Linear(0, 0.25, 1))
+func Linear(outputs ...float32) EasingAlgorithm {
+	stops := make([]LinearStop, len(outputs))
+
+	for i, output := range outputs {
+		stops[i].Output = output
+		if len(outputs) > 1 {
+			stops[i].Input = float32(i) / float32(len(outputs)-1)
+		}
+	}
+
+	return LinearStops(stops...)
+}
+
+// LinearStops returns a piecewise-linear EasingAlgorithm going through stops
+// like CSS linear() timing function with stop positions.
+// Stops are expected to be sorted by Input; an Input lower than the previous one
+// is raised to it (what makes a jump). Progress out of stops' range is extrapolated
+// from the first or last two stops.
+// With no stops it is linear, with one stop it is constant.
+func LinearStops(stops ...LinearStop) EasingAlgorithm {
+	points := make([]LinearStop, len(stops))
+	copy(points, stops)
+
+	for i := 1; i < len(points); i++ {
+		points[i].Input = max(points[i].Input, points[i-1].Input)
+	}
+
+	return func(p float32) float32 {
+		switch len(points) {
+		case 0:
+			return p
+		case 1:
+			return points[0].Output
+		}
+
+		// find the last segment starting at or before p
+		// (the first one if p is before all stops).
+		i := 0
+		for i < len(points)-2 && points[i+1].Input <= p {
+			i++
+		}
+
+		prev, next := points[i], points[i+1]
+
+		if next.Input == prev.Input {
+			return next.Output
+		}
+
+		return prev.Output + (next.Output-prev.Output)*(p-prev.Input)/(next.Input-prev.Input)
+	}
+}
@@ -1,13 +1,18 @@
 package animations
 
 import (
-	"log"
+	"fmt"
 
 	"github.com/AllenDang/cimgui-go/imgui"
 	"github.com/AllenDang/giu"
 )
 
-var _ Animation = &MoveAnimation{}
+var (
+	_ Animation     = &MoveAnimation{}
+	_ KeyFrameTimer = &MoveAnimation{}
+	_ MotionReducer = &MoveAnimation{}
+	_ Validator     = &MoveAnimation{}
+)
 
 // MoveAnimation moves animation widget from start position to destination.
 // You can also specify animation Bézier curve's points.
@@ -56,17 +61,62 @@ func (m *MoveAnimation) Reset() {
 }
 
 // KeyFramesCount implements Animation interface.
+// Steps exceeding maximal number of key frames are ignored (see Validate).
 func (m *MoveAnimation) KeyFramesCount() KeyFrame {
+	return KeyFrame(min(m.stepsCount(), keyFrameMaxSize))
+}
+
+// Validate implements Validator.
+func (m *MoveAnimation) Validate() error {
+	if m.widget == nil {
+		return ErrNilWidget
+	}
+
+	if err := validateKeyFramesCount(m.stepsCount()); err != nil {
+		return err
+	}
+
+	for i, s := range m.steps {
+		if s == nil {
+			return fmt.Errorf("step %d: %w", i, ErrNilKeyFrame)
+		}
+	}
+
+	return nil
+}
+
+// stepsCount returns number of steps including start step.
+func (m *MoveAnimation) stepsCount() int {
 	l := len(m.steps)
 	if m.startStep != nil {
 		l++
 	}
 
-	if l > keyFrameMaxSize {
-		panic("Too many KeyFrames")
+	return l
+}
+
+// KeyFrameTiming implements KeyFrameTimer.
+// Timing of the step returned by StartPos callback is not used
+// (its key frame is played with animator's timing).
+// It doesn't call StartPos callback nor access giu state, because
+// it is called while the animation is advanced (also outside of the render thread).
+func (m *MoveAnimation) KeyFrameTiming(kf KeyFrame) KeyFrameTiming {
+	i := int(kf)
+	if m.startStep != nil {
+		i--
 	}
 
-	return KeyFrame(l)
+	if i < 0 || i >= len(m.steps) || m.steps[i] == nil {
+		return KeyFrameTiming{}
+	}
+
+	return m.steps[i].timing
+}
+
+// MotionReduction implements MotionReducer.
+// With reduced motion, the widget jumps to its destination.
+func (m *MoveAnimation) MotionReduction() MotionReduction {
+	return MotionReductionInstant
 }
 
 // BuildNormal implements Animation.
@@ -175,11 +225,11 @@ func (m *MoveAnimation) getSteps() []*MoveStep {
 func (m *MoveAnimation) getState() *moveAnimationState {
 	if s := giu.Context.GetState(m.id); s != nil {
 		state, ok := s.(*moveAnimationState)
-		if !ok {
-			log.Panicf("error asserting type of move animation state: got %T, wanted *moveAnimationState", s)
+		if ok {
+			return state
 		}
 
-		return state
+		reportError(fmt.Errorf("move animation %s: %w: got %T, wanted *moveAnimationState", m.id, ErrInvalidState, s))
 	}
 
 	giu.Context.SetState(m.id, m.newState())
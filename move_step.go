@@ -1,6 +1,10 @@
 package animations
 
-import "github.com/AllenDang/cimgui-go/imgui"
+import (
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
 
 // MoveStep represents animation single key frame in context of MoveAnimation.
 // If Relative() not set, positionDelta is relative to this in animation previous step.
@@ -10,6 +14,8 @@ type MoveStep struct {
 
 	useBezier bool
 	bezier    []imgui.Vec2
+
+	timing KeyFrameTiming
 }
 
 // Step creates animation new instance of MoveStep.
@@ -42,3 +48,18 @@ func (m *MoveStep) Absolute() *MoveStep {
 
 	return m
 }
+
+// Duration sets duration of moving to this step (see KeyFrameTiming).
+func (m *MoveStep) Duration(d time.Duration) *MoveStep {
+	m.timing.Duration = d
+
+	return m
+}
+
+// EasingAlgorithm sets easing algorithm of moving to this step (see KeyFrameTiming).
+func (m *MoveStep) EasingAlgorithm(alg EasingAlgorithmType) *MoveStep {
+	m.timing.EasingAlgorithm = alg
+	m.timing.OverrideEasing = true
+
+	return m
+}
@@ -0,0 +1,29 @@
+package animations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestMoveAnimation_KeyFrameTiming(t *testing.T) {
+	startCalls := 0
+
+	m := Move(nil, Step(0, 0).Duration(time.Second), Step(1, 1).Duration(2*time.Second)).
+		StartPos(func(startPos imgui.Vec2) *MoveStep {
+			startCalls++
+
+			return StepVec(startPos).Duration(time.Hour)
+		})
+
+	for kf, want := range []time.Duration{0, time.Second, 2 * time.Second, 0} {
+		if got := m.KeyFrameTiming(KeyFrame(kf)).Duration; got != want {
+			t.Errorf("KeyFrameTiming(%v).Duration = %v, want %v", kf, got, want)
+		}
+	}
+
+	if startCalls != 0 {
+		t.Errorf("KeyFrameTiming should not call StartPos callback, got %v calls", startCalls)
+	}
+}
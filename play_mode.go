@@ -8,4 +8,26 @@ const (
 	PlayForward PlayMode = iota
 	// PlayBackward plays an animation from 1 to 0 percentage progress.
 	PlayBackward
+	// PlayPingPong plays an animation forward and then backward to the key frame it started from.
+	// NOTE: Animation.BuildAnimation never receives this value - it gets PlayForward or PlayBackward
+	// depending on the part of ping-pong being played.
+	PlayPingPong
 )
+
+// direction returns PlayForward or PlayBackward - a direction the animation starts in.
+func (p PlayMode) direction() PlayMode {
+	if p == PlayPingPong {
+		return PlayForward
+	}
+
+	return p
+}
+
+// playModeDelta returns a key frame delta for the play mode.
+func playModeDelta(playMode PlayMode) KeyFrame {
+	if playMode == PlayBackward {
+		return -1
+	}
+
+	return 1
+}
@@ -0,0 +1,72 @@
+package animations
+
+import "time"
+
+// ReducedMotionDuration is the maximal duration of a key frame
+// played with MotionReductionShort.
+const ReducedMotionDuration = 150 * time.Millisecond
+
+// SetReducedMotion enables or disables reduced motion for all animations.
+// It should be enabled for users preferring reduced motion (e.g. because of motion sickness).
+// When enabled, animations are degraded as they declare (see MotionReducer),
+// but they still reach their key frames and call their callbacks.
+// It takes effect from the next key frame of running animations.
+func SetReducedMotion(enabled bool) {
+	reducedMotion.Store(enabled)
+}
+
+// ReducedMotionEnabled returns true if reduced motion is enabled (see SetReducedMotion).
+func ReducedMotionEnabled() bool {
+	return reducedMotion.Load()
+}
+
+// MotionReduction describes how an animation is played when reduced motion is enabled.
+type MotionReduction byte
+
+const (
+	// MotionReductionInstant makes key frames reached instantly (without intermediate frames).
+	// This is default for animations not implementing MotionReducer (e.g. MoveAnimation jumps to its destination).
+	MotionReductionInstant MotionReduction = iota
+	// MotionReductionShort makes key frames take at most ReducedMotionDuration with no easing
+	// (e.g. TransitionAnimation becomes a plain short cross-fade).
+	MotionReductionShort
+	// MotionReductionNone plays the animation normally. It is for animations that do not
+	// involve motion (e.g. ColorFlowAnimation).
+	MotionReductionNone
+)
+
+// MotionReducer could be implemented by an Animation in order to
+// declare how it degrades when reduced motion is enabled.
+// It could be overridden by (*AnimatorWidget).MotionReduction.
+type MotionReducer interface {
+	MotionReduction() MotionReduction
+}
+
+// reduceMotion applies animator's MotionReduction to the current key frame's timing
+// if reduced motion is enabled.
+// It must be called with state.m locked.
+func (a *AnimatorWidget) reduceMotion(state *animatorState) {
+	if !ReducedMotionEnabled() {
+		return
+	}
+
+	reduction := MotionReductionInstant
+
+	switch r, ok := a.animation.(MotionReducer); {
+	case a.overrideMotionReduction:
+		reduction = a.motionReduction
+	case ok:
+		reduction = r.MotionReduction()
+	}
+
+	switch reduction {
+	case MotionReductionInstant:
+		state.duration = 0
+	case MotionReductionShort:
+		state.duration = min(state.duration, ReducedMotionDuration)
+		state.easingAlgorithm = EasingAlgNone
+		state.easingFunc = nil
+	case MotionReductionNone:
+		// noop
+	}
+}
@@ -0,0 +1,90 @@
+package animations
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReducedMotion(t *testing.T) {
+	SetReducedMotion(true)
+	t.Cleanup(func() { SetReducedMotion(false) })
+
+	a, anim, _ := newTestAnimator(t, 3)
+	a.Driver(DriverFrame)
+
+	finished := 0
+	a.OnFinish(func() { finished++ })
+
+	// testAnimation does not implement MotionReducer, so key frames are reached instantly.
+	a.StartKeyFrames(0, 2, 0, PlayForward)
+	a.Build()
+
+	if a.IsRunning() {
+		t.Fatal("animation should finish instantly with reduced motion")
+	}
+
+	if finished != 1 {
+		t.Errorf("OnFinish should be called once, got %v", finished)
+	}
+
+	anim.m.Lock()
+	if anim.animationCalls != 0 || anim.base != 2 {
+		t.Errorf("animation should jump to key frame 2: %v BuildAnimation calls, key frame %v", anim.animationCalls, anim.base)
+	}
+	anim.m.Unlock()
+
+	b, bAnim, clock := newTestAnimator(t, 2)
+	b.ID(b.id + "-short").Driver(DriverFrame).EasingAlgorithm(EasingAlgInQuad).MotionReduction(MotionReductionShort)
+
+	b.Start(PlayForward)
+	clock.Advance(ReducedMotionDuration / 2)
+
+	if p := b.CurrentPercentageProgress(); !almostEqual(p, 0.5) {
+		t.Errorf("with MotionReductionShort: progress = %v, want 0.5", p)
+	}
+
+	b.Build()
+
+	bAnim.m.Lock()
+	defer bAnim.m.Unlock()
+
+	if bAnim.animationCalls != 1 || !almostEqual(bAnim.percentage, 0.5) {
+		t.Errorf("MotionReductionShort should play linearly: %v BuildAnimation calls, %v%%", bAnim.animationCalls, bAnim.percentage)
+	}
+}
+
+func TestReducedMotion_Cycles(t *testing.T) {
+	SetReducedMotion(true)
+	t.Cleanup(func() { SetReducedMotion(false) })
+
+	var reported error
+
+	SetErrorHandler(func(err error) { reported = err })
+	t.Cleanup(func() { SetErrorHandler(LogError) })
+
+	a, _, _ := newTestAnimator(t, 3)
+	a.Driver(DriverFrame)
+
+	a.StartCycle(-1, PlayForward)
+	a.Build()
+
+	if !errors.Is(reported, ErrInvalidCycles) {
+		t.Errorf("StartCycle(-1) should report ErrInvalidCycles, got %v", reported)
+	}
+
+	if a.IsRunning() {
+		t.Fatal("animation with negative number of cycles should not start")
+	}
+
+	// zero-duration key frames are reached one pass per Build.
+	a.StartCycle(1000, PlayForward)
+	a.Build()
+
+	if !a.IsRunning() {
+		t.Error("long animation should not finish in one Build")
+	}
+
+	if kf := a.CurrentKeyFrame(); kf != 0 {
+		t.Errorf("one pass through all key frames should end on key frame 0, got %v", kf)
+	}
+}
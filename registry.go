@@ -0,0 +1,175 @@
+package animations
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AllenDang/giu"
+)
+
+// animatorRegistry holds the most recently built AnimatorWidget of every ID along with its state.
+// Animators are added in Build and removed when giu disposes their state.
+// It also detects different animations built under the same ID (see SetDuplicateIDHandler).
+type animatorRegistry struct {
+	animators map[giu.ID]registryEntry
+	// frames holds number of the frame in which an animator was built last time.
+	frames map[giu.ID]int32
+	// duplicates holds the last reported conflict of every ID (see SetDuplicateIDHandler).
+	duplicates    map[giu.ID]duplicateReport
+	onDuplicateID DuplicateIDHandler
+	m             *sync.Mutex
+}
+
+// registryEntry is an animator with its state. The state is kept, so that the registry
+// could control the animator without accessing giu state (what would keep it alive).
+type registryEntry struct {
+	animator *AnimatorWidget
+	state    *animatorState
+}
+
+func newAnimatorRegistry() *animatorRegistry {
+	return &animatorRegistry{
+		animators:     make(map[giu.ID]registryEntry),
+		frames:        make(map[giu.ID]int32),
+		duplicates:    make(map[giu.ID]duplicateReport),
+		onDuplicateID: LogDuplicateID,
+		m:             &sync.Mutex{},
+	}
+}
+
+func (r *animatorRegistry) register(a *AnimatorWidget, state *animatorState) {
+	frame := frameCount()
+
+	r.m.Lock()
+
+	prev, ok := r.animators[a.id]
+	duplicate := ok && r.frames[a.id] == frame && !sameAnimation(prev.animator.animation, a.animation)
+	onDuplicateID := r.onDuplicateID
+
+	if duplicate {
+		duplicate = r.shouldReportDuplicate(a.id, frame, prev.animator.animation, a.animation)
+	}
+
+	r.animators[a.id] = registryEntry{animator: a, state: state}
+	r.frames[a.id] = frame
+
+	r.m.Unlock()
+
+	if duplicate && onDuplicateID != nil {
+		onDuplicateID(a.id, prev.animator.animation, a.animation)
+	}
+}
+
+// unregister removes the animator of state (if state was not replaced in the meantime).
+func (r *animatorRegistry) unregister(state *animatorState) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if e, ok := r.animators[state.id]; !ok || e.state != state {
+		return
+	}
+
+	delete(r.animators, state.id)
+	delete(r.frames, state.id)
+	delete(r.duplicates, state.id)
+}
+
+// Animators returns all AnimatorWidgets being built (sorted by ID).
+// An animator is listed after it is built for the first time
+// until it stops being built (see giu.Disposable).
+// It is useful e.g. for debugging (see also Inspector).
+// Returned animators are copies bound to the state of the animator: controlling them
+// doesn't keep the animator alive, so they shouldn't be built nor used after it stops being built.
+func Animators() []*AnimatorWidget {
+	registry.m.Lock()
+
+	result := make([]*AnimatorWidget, 0, len(registry.animators))
+	for _, e := range registry.animators {
+		bound := *e.animator
+		bound.state = e.state
+		result = append(result, &bound)
+	}
+
+	registry.m.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+
+	return result
+}
+
+// AnimatorInfo is a snapshot of AnimatorWidget's state (see (*AnimatorWidget).Info).
+type AnimatorInfo struct {
+	ID            giu.ID
+	AnimationType string
+
+	IsRunning bool
+	IsPaused  bool
+
+	CurrentKeyFrame     KeyFrame
+	DestinationKeyFrame KeyFrame
+	KeyFramesCount      KeyFrame
+	// Progress of the current key frame (0 if not running).
+	Progress float32
+
+	// EasingAlgorithm and Duration of the current key frame
+	// (animator's ones if not running).
+	EasingAlgorithm EasingAlgorithmType
+	Duration        time.Duration
+	FPS             int
+	Driver          Driver
+}
+
+// Info returns current state of the animator.
+func (a *AnimatorWidget) Info() AnimatorInfo {
+	result := AnimatorInfo{
+		ID:              a.id,
+		AnimationType:   fmt.Sprintf("%T", a.animation),
+		KeyFramesCount:  a.numKeyFrames,
+		EasingAlgorithm: a.easingAlgorithm,
+		Duration:        a.duration,
+		FPS:             a.fps,
+		Driver:          a.driver,
+	}
+
+	s := a.getState()
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	result.IsRunning = s.isRunning
+	result.IsPaused = s.isRunning && s.isPaused
+	result.CurrentKeyFrame = s.currentKeyFrame
+	result.DestinationKeyFrame = s.currentKeyFrame
+
+	if s.isRunning {
+		s.update(a.clock.Now(), a.timeScale())
+		result.DestinationKeyFrame = s.destinationKeyFrame
+		result.Progress = s.progress()
+		result.EasingAlgorithm = s.easingAlgorithm
+		result.Duration = s.duration
+	}
+
+	return result
+}
+
+// Replay plays the last started animation again (see Repeat to find out what does it mean).
+// If the animation was never started, it starts it forwards.
+func (a *AnimatorWidget) Replay() {
+	s := a.getState()
+
+	s.m.Lock()
+	rerun := s.rerun
+	s.m.Unlock()
+
+	if rerun == nil {
+		a.Start(PlayForward)
+
+		return
+	}
+
+	rerun(a)
+}
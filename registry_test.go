@@ -0,0 +1,175 @@
+package animations
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/AllenDang/giu"
+)
+
+func TestAnimators(t *testing.T) {
+	a, _, clock := newTestAnimator(t, 3)
+	a.Driver(DriverFrame)
+
+	a.Build()
+
+	found := false
+
+	for _, animator := range Animators() {
+		if animator.id == a.id {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Fatal("animator should be registered after Build")
+	}
+
+	a.StartKeyFrames(0, 2, 0, PlayForward)
+	clock.Advance(time.Second / 2)
+
+	info := a.Info()
+	if !info.IsRunning || info.CurrentKeyFrame != 0 || info.DestinationKeyFrame != 1 ||
+		info.KeyFramesCount != 3 || !almostEqual(info.Progress, 0.5) || info.Duration != time.Second {
+		t.Errorf("unexpected Info(): %+v", info)
+	}
+
+	a.Stop()
+	a.Replay()
+
+	if info := a.Info(); !info.IsRunning || info.CurrentKeyFrame != 0 || info.Progress != 0 {
+		t.Errorf("Replay should start the animation from key frame 0 again: %+v", info)
+	}
+
+	// two frames without building the animator: giu disposes its state.
+	for i := 0; i < 2; i++ {
+		giu.Context.SetDirty()
+		giu.Context.GetState(giu.ID(t.Name() + "-other"))
+	}
+
+	for _, animator := range Animators() {
+		if animator.id == a.id {
+			t.Fatal("animator should be removed when its state is disposed")
+		}
+	}
+}
+
+func TestAnimators_DoNotKeepStateAlive(t *testing.T) {
+	a, _, _ := newTestAnimator(t, 3)
+	a.Repeat(RepeatForever)
+
+	a.Start(PlayForward)
+	a.Build()
+
+	s := a.getState()
+
+	var inspected *AnimatorWidget
+
+	// frames without building the animator, but with inspecting it (like Inspector does).
+	for i := 0; i < 5; i++ {
+		giu.Context.SetDirty()
+
+		for _, animator := range Animators() {
+			if animator.id == a.id {
+				inspected = animator
+
+				animator.Info()
+				animator.Pause()
+				animator.Seek(0.5)
+				animator.Replay()
+			}
+		}
+
+		giu.Context.GetState(giu.ID(t.Name() + "-other"))
+	}
+
+	for _, animator := range Animators() {
+		if animator.id == a.id {
+			t.Fatal("animator should be removed when it is not built anymore")
+		}
+	}
+
+	// disposed animator could not be started again.
+	inspected.Replay()
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.isRunning {
+		t.Error("playback of disposed animator should be stopped")
+	}
+}
+
+func TestDuplicateID(t *testing.T) {
+	frame := int32(0)
+	frameCount = func() int32 { return frame }
+
+	type conflict struct {
+		id            giu.ID
+		first, second Animation
+	}
+
+	var conflicts []conflict
+
+	SetDuplicateIDHandler(func(id giu.ID, first, second Animation) {
+		conflicts = append(conflicts, conflict{id, first, second})
+	})
+
+	t.Cleanup(func() {
+		frameCount = imgui.FrameCount
+		SetDuplicateIDHandler(LogDuplicateID)
+	})
+
+	first := newTestAnimation(2)
+	second := Transition(func(StarterFunc) {}, func(StarterFunc) {})
+	id := giu.ID(t.Name())
+
+	// the same animation built in every frame is fine
+	frame = 1
+	Animator(first).ID(id).Build()
+
+	frame = 2
+	Animator(first).ID(id).Build()
+
+	if len(conflicts) != 0 {
+		t.Fatalf("unexpected conflicts: %v", conflicts)
+	}
+
+	Animator(second).ID(id).Build()
+
+	if len(conflicts) != 1 {
+		t.Fatalf("expected 1 conflict, got %v", len(conflicts))
+	}
+
+	if c := conflicts[0]; c.id != id || c.first != first || c.second != second {
+		t.Errorf("unexpected conflict: %+v", c)
+	}
+
+	// the same conflict is reported once until it goes away.
+	for frame = 3; frame < 6; frame++ {
+		Animator(first).ID(id).Build()
+		Animator(second).ID(id).Build()
+	}
+
+	if len(conflicts) != 1 {
+		t.Fatalf("the same conflict should be reported once, got %v conflicts", len(conflicts))
+	}
+
+	frame++
+	Animator(first).ID(id).Build()
+
+	frame++
+	Animator(first).ID(id).Build()
+	Animator(second).ID(id).Build()
+
+	if len(conflicts) != 2 {
+		t.Fatalf("conflict should be reported again after it went away, got %v conflicts", len(conflicts))
+	}
+
+	if msg := duplicateIDMessage(id, first, second); !strings.Contains(msg, "*animations.testAnimation") ||
+		!strings.Contains(msg, "*animations.TransitionAnimation") {
+		t.Errorf("message should name conflicting types: %q", msg)
+	}
+}
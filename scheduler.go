@@ -0,0 +1,199 @@
+package animations
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/AllenDang/giu"
+)
+
+// scheduler keeps track of all running animations.
+// Animations using DriverTicker are advanced by the scheduler:
+// these using the same Clock and FPS are grouped and advanced by one goroutine
+// that calls giu.Update at most once per tick.
+// A group's goroutine exits as soon as there is nothing to advance.
+type scheduler struct {
+	playbacks map[*animatorState]scheduledPlayback
+	groups    map[schedulerKey]*tickGroup
+	groupOf   map[*animatorState]*tickGroup
+	m         *sync.Mutex
+}
+
+// schedulerKey identifies a tickGroup.
+// Clocks that could not be used as a map key (e.g. functions or structs with slices)
+// are not shared: such a group is identified by its only state instead.
+type schedulerKey struct {
+	clock    Clock
+	interval time.Duration
+	state    *animatorState
+}
+
+// tickGroup is a set of playbacks advanced on every tick of the same ticker.
+type tickGroup struct {
+	key       schedulerKey
+	clock     Clock
+	playbacks map[*animatorState]scheduledPlayback
+	quit      chan bool
+}
+
+// scheduledPlayback is a playback (see animatorState.stop) of an animator.
+type scheduledPlayback struct {
+	animator *AnimatorWidget
+	stop     chan bool
+}
+
+func newScheduler() *scheduler {
+	return &scheduler{
+		playbacks: make(map[*animatorState]scheduledPlayback),
+		groups:    make(map[schedulerKey]*tickGroup),
+		groupOf:   make(map[*animatorState]*tickGroup),
+		m:         &sync.Mutex{},
+	}
+}
+
+// schedule adds playback identified by stop to running animations.
+// With DriverTicker, it is added to the group of animator's clock and FPS.
+// Previous playback of the state (if any) is replaced.
+func (s *scheduler) schedule(a *AnimatorWidget, state *animatorState, stop chan bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.unscheduleLocked(state)
+
+	playback := scheduledPlayback{animator: a, stop: stop}
+	s.playbacks[state] = playback
+
+	if a.driver != DriverTicker {
+		return
+	}
+
+	fps := a.fps
+	if fps <= 0 {
+		// invalid FPS (see (*AnimatorWidget).Validate)
+		fps = DefaultFPS
+	}
+
+	key := schedulerKey{
+		// at least 1ns for huge FPS
+		interval: max(time.Second/time.Duration(fps), 1),
+	}
+
+	if reflect.ValueOf(a.clock).Comparable() {
+		key.clock = a.clock
+	} else {
+		key.state = state
+	}
+
+	group, ok := s.groups[key]
+	if !ok {
+		group = &tickGroup{
+			key:       key,
+			clock:     a.clock,
+			playbacks: make(map[*animatorState]scheduledPlayback),
+			quit:      make(chan bool),
+		}
+
+		s.groups[key] = group
+
+		go s.run(group, group.clock.NewTicker(key.interval))
+	}
+
+	group.playbacks[state] = playback
+	s.groupOf[state] = group
+}
+
+// unschedule removes playback of state from the scheduler.
+// It may be called with state.m locked.
+func (s *scheduler) unschedule(state *animatorState) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.unscheduleLocked(state)
+}
+
+func (s *scheduler) unscheduleLocked(state *animatorState) {
+	delete(s.playbacks, state)
+
+	group, ok := s.groupOf[state]
+	if !ok {
+		return
+	}
+
+	delete(s.groupOf, state)
+	delete(group.playbacks, state)
+
+	if len(group.playbacks) == 0 {
+		delete(s.groups, group.key)
+		close(group.quit)
+	}
+}
+
+// running returns a copy of all running playbacks.
+func (s *scheduler) running() map[*animatorState]scheduledPlayback {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	result := make(map[*animatorState]scheduledPlayback, len(s.playbacks))
+	for state, p := range s.playbacks {
+		result[state] = p
+	}
+
+	return result
+}
+
+// run advances group on every tick until the group gets empty.
+func (s *scheduler) run(group *tickGroup, ticker Ticker) {
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			s.tick(group, group.clock.Now())
+		case <-group.quit:
+			return
+		}
+	}
+}
+
+// tick advances all playbacks of group to the time now and requests a redraw if necessary.
+func (s *scheduler) tick(group *tickGroup, now time.Time) {
+	s.m.Lock()
+
+	states := make([]*animatorState, 0, len(group.playbacks))
+	playbacks := make([]scheduledPlayback, 0, len(group.playbacks))
+
+	for state, p := range group.playbacks {
+		states = append(states, state)
+		playbacks = append(playbacks, p)
+	}
+
+	s.m.Unlock()
+
+	redraw := false
+
+	for i, p := range playbacks {
+		state := states[i]
+
+		if !p.animator.advance(state, p.stop, now) {
+			// build animation normally at least once (before Power Saving Mechanism freezes updating)
+			// This is important mainly because of triggers that might have to be run.
+			redraw = true
+
+			continue
+		}
+
+		state.m.Lock()
+		idle := state.isPaused || state.isWaiting() || p.animator.timeScale() == 0
+		state.m.Unlock()
+
+		// nothing changes while paused or waiting
+		if !idle {
+			redraw = true
+		}
+	}
+
+	if redraw {
+		giu.Update()
+	}
+}
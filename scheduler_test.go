@@ -0,0 +1,206 @@
+package animations
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AllenDang/giu"
+)
+
+func TestScheduler_Groups(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+
+	animators := make([]*AnimatorWidget, 0, 3)
+
+	for i, fps := range []int{30, 30, 60} {
+		a, _, _ := newTestAnimator(t, 2)
+		a.ID(giu.ID(fmt.Sprintf("%s%d", t.Name(), i))).Clock(clock).FPS(fps).Repeat(RepeatForever)
+		a.Start(PlayForward)
+
+		animators = append(animators, a)
+	}
+
+	sharedScheduler.m.Lock()
+	groups := len(sharedScheduler.groups)
+	sharedScheduler.m.Unlock()
+
+	if groups != 2 {
+		t.Errorf("animators should be grouped by FPS: got %v groups, want 2", groups)
+	}
+
+	for _, a := range animators {
+		a.Stop()
+	}
+
+	sharedScheduler.m.Lock()
+	groups, scheduled := len(sharedScheduler.groups), len(sharedScheduler.groupOf)
+	sharedScheduler.m.Unlock()
+
+	if groups != 0 || scheduled != 0 {
+		t.Errorf("stopped animators should be unscheduled: got %v groups, %v animators", groups, scheduled)
+	}
+}
+
+func TestScheduler_HugeFPS(t *testing.T) {
+	a, _, clock := newTestAnimator(t, 2)
+	a.FPS(2_000_000_000)
+
+	if err := a.Validate(); err != nil {
+		t.Fatalf("huge FPS should be valid: %v", err)
+	}
+
+	a.Start(PlayForward)
+	advanceUntilStopped(t, a, clock)
+}
+
+// sliceClock is a Clock that could not be used as a map key.
+type sliceClock struct {
+	clocks []*FakeClock
+}
+
+func (c sliceClock) Now() time.Time {
+	return c.clocks[0].Now()
+}
+
+func (c sliceClock) NewTicker(d time.Duration) Ticker {
+	return c.clocks[0].NewTicker(d)
+}
+
+func TestScheduler_NonComparableClock(t *testing.T) {
+	fake := NewFakeClock(time.Unix(0, 0))
+	clock := sliceClock{clocks: []*FakeClock{fake}}
+
+	animators := make([]*AnimatorWidget, 0, 2)
+
+	for i := 0; i < 2; i++ {
+		a, _, _ := newTestAnimator(t, 2)
+		a.ID(giu.ID(fmt.Sprintf("%s%d", t.Name(), i))).Clock(clock)
+		a.Start(PlayForward)
+
+		animators = append(animators, a)
+	}
+
+	sharedScheduler.m.Lock()
+	groups := len(sharedScheduler.groups)
+	sharedScheduler.m.Unlock()
+
+	if groups != 2 {
+		t.Errorf("animators with non-comparable clocks should get their own groups: got %v groups, want 2", groups)
+	}
+
+	for _, a := range animators {
+		advanceUntilStopped(t, a, fake)
+	}
+
+	sharedScheduler.m.Lock()
+	groups = len(sharedScheduler.groups)
+	sharedScheduler.m.Unlock()
+
+	if groups != 0 {
+		t.Errorf("finished animators should be unscheduled: got %v groups", groups)
+	}
+}
+
+// newBenchmarkAnimators creates n long-running animators.
+func newBenchmarkAnimators(b *testing.B, clock *FakeClock, driver Driver, n int) []*AnimatorWidget {
+	b.Helper()
+
+	animators := make([]*AnimatorWidget, n)
+
+	for i := range animators {
+		animators[i] = Animator(newTestAnimation(2)).
+			ID(giu.ID(fmt.Sprintf("%s%d", b.Name(), i))).
+			Clock(clock).
+			Driver(driver).
+			Duration(time.Hour).
+			Repeat(RepeatForever)
+		animators[i].Start(PlayForward)
+	}
+
+	b.Cleanup(func() {
+		for _, a := range animators {
+			a.Stop()
+		}
+	})
+
+	return animators
+}
+
+// BenchmarkScheduler_Shared measures a single tick of the shared scheduler.
+func BenchmarkScheduler_Shared(b *testing.B) {
+	for _, n := range []int{100, 1000, 5000} {
+		b.Run(fmt.Sprintf("animators=%d", n), func(b *testing.B) {
+			clock := NewFakeClock(time.Unix(0, 0))
+			newBenchmarkAnimators(b, clock, DriverTicker, n)
+
+			sharedScheduler.m.Lock()
+			group := sharedScheduler.groups[schedulerKey{clock: clock, interval: time.Second / DefaultFPS}]
+			sharedScheduler.m.Unlock()
+
+			now := clock.Now()
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				now = now.Add(time.Second / DefaultFPS)
+				sharedScheduler.tick(group, now)
+			}
+		})
+	}
+}
+
+// BenchmarkScheduler_PerAnimator measures a single tick delivered to
+// a goroutine per animator, each requesting its own redraw.
+// This is how animators were advanced before the shared scheduler.
+func BenchmarkScheduler_PerAnimator(b *testing.B) {
+	for _, n := range []int{100, 1000, 5000} {
+		b.Run(fmt.Sprintf("animators=%d", n), func(b *testing.B) {
+			clock := NewFakeClock(time.Unix(0, 0))
+			animators := newBenchmarkAnimators(b, clock, DriverFrame, n)
+
+			wg := &sync.WaitGroup{}
+			ticks := make([]chan time.Time, n)
+
+			for i, a := range animators {
+				ticks[i] = make(chan time.Time)
+
+				state := a.getState()
+				state.m.Lock()
+				stop := state.stop
+				state.m.Unlock()
+
+				go func(a *AnimatorWidget, c chan time.Time) {
+					for now := range c {
+						a.advance(state, stop, now)
+						giu.Update()
+						wg.Done()
+					}
+				}(a, ticks[i])
+			}
+
+			b.Cleanup(func() {
+				for _, c := range ticks {
+					close(c)
+				}
+			})
+
+			now := clock.Now()
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				now = now.Add(time.Second / DefaultFPS)
+
+				wg.Add(n)
+
+				for _, c := range ticks {
+					c <- now
+				}
+
+				wg.Wait()
+			}
+		})
+	}
+}
@@ -5,4 +5,12 @@ type StarterFunc interface {
 	Start(mode PlayMode)
 	StartKeyFrames(beginKF, destinyKF KeyFrame, cyclesCount int, mode PlayMode)
 	StartCycle(cyclesCount int, mode PlayMode)
+	Stop()
+	Pause()
+	Resume()
+	Seek(progress float32)
+	SeekKeyFrame(kf KeyFrame)
+	SetKeyFrame(kf KeyFrame)
+	CurrentKeyFrame() KeyFrame
+	CanAdvance(mode PlayMode) bool
 }
@@ -0,0 +1,59 @@
+package animations
+
+import "math"
+
+// StepJump tells Steps when the jumps between steps occur.
+// It corresponds to CSS steps() jump terms.
+type StepJump byte
+
+const (
+	// StepJumpEnd is the default. The first step is held from the beginning
+	// and the last jump (to 1) occurs at the end.
+	StepJumpEnd StepJump = iota
+	// StepJumpStart makes the first jump occur at the beginning.
+	StepJumpStart
+	// StepJumpBoth makes jumps occur both at the beginning and at the end
+	// (n+1 jumps, n steps between them).
+	StepJumpBoth
+	// StepJumpNone holds both 0 and 1 for 1/n of duration each (n-1 jumps).
+	StepJumpNone
+)
+
+// Steps returns an EasingAlgorithm dividing the animation into n equal steps
+// exactly like CSS steps(n, jump) timing function. It is useful for sprite-like animations
+// (e.g. ColorFlow ticking between colors) or segmented progress indicators.
+// n is at least 1 (2 for StepJumpNone).
+//
+//	Example: animator.EasingFunc(animations.Steps(4, animations.StepJumpEnd))
+func Steps(n int, jump StepJump) EasingAlgorithm {
+	n = max(n, 1)
+	jumps := n
+
+	switch jump {
+	case StepJumpEnd, StepJumpStart:
+		// noop
+	case StepJumpBoth:
+		jumps = n + 1
+	case StepJumpNone:
+		n = max(n, 2)
+		jumps = n - 1
+	}
+
+	return func(p float32) float32 {
+		step := math.Floor(float64(p) * float64(n))
+
+		if jump == StepJumpStart || jump == StepJumpBoth {
+			step++
+		}
+
+		if p >= 0 && step < 0 {
+			step = 0
+		}
+
+		if p <= 1 && step > float64(jumps) {
+			step = float64(jumps)
+		}
+
+		return float32(step / float64(jumps))
+	}
+}
@@ -0,0 +1,77 @@
+package animations
+
+import (
+	"sync"
+	"time"
+
+	"github.com/AllenDang/giu"
+)
+
+// timeSettings are time settings applied to all animations (see globalTime).
+type timeSettings struct {
+	scale  float64
+	paused bool
+	m      *sync.RWMutex
+}
+
+// TimeScale sets speed of all animations (e.g. 0.1 plays them 10 times slower).
+// It multiplies with animator's own speed (see (*AnimatorWidget).Speed).
+// Default is 1. Negative values are treated as 0.
+func TimeScale(factor float64) {
+	setGlobalTime(func(t *timeSettings) {
+		t.scale = max(factor, 0)
+	})
+}
+
+// PauseAll pauses all animations (including these started later) until ResumeAll is called.
+// It does not affect (*AnimatorWidget).IsPaused.
+func PauseAll() {
+	setGlobalTime(func(t *timeSettings) {
+		t.paused = true
+	})
+}
+
+// ResumeAll resumes animations paused by PauseAll.
+// Animations paused with (*AnimatorWidget).Pause stay paused.
+func ResumeAll() {
+	setGlobalTime(func(t *timeSettings) {
+		t.paused = false
+	})
+}
+
+// setGlobalTime changes global time settings with f.
+// Running animations are updated first, so that the change applies from now on.
+func setGlobalTime(f func(t *timeSettings)) {
+	for state, p := range sharedScheduler.running() {
+		state.m.Lock()
+		state.update(p.animator.clock.Now(), p.animator.timeScale())
+		state.m.Unlock()
+	}
+
+	globalTime.m.Lock()
+	f(globalTime)
+	globalTime.m.Unlock()
+
+	giu.Update()
+}
+
+// globalTimeScale returns time scale applied to all animations (0 if paused by PauseAll).
+func globalTimeScale() float64 {
+	globalTime.m.RLock()
+	defer globalTime.m.RUnlock()
+
+	if globalTime.paused {
+		return 0
+	}
+
+	return globalTime.scale
+}
+
+// scaleDuration returns d multiplied by scale.
+func scaleDuration(d time.Duration, scale float64) time.Duration {
+	if scale == 1 {
+		return d
+	}
+
+	return time.Duration(float64(d) * scale)
+}
@@ -0,0 +1,44 @@
+package animations
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeScale(t *testing.T) {
+	a, _, clock := newTestAnimator(t, 2)
+	a.Driver(DriverFrame)
+
+	t.Cleanup(func() {
+		TimeScale(1)
+		ResumeAll()
+	})
+
+	TimeScale(0.5)
+	a.Start(PlayForward)
+
+	clock.Advance(time.Second / 2)
+
+	if p := a.CurrentPercentageProgress(); !almostEqual(p, 0.25) {
+		t.Errorf("with TimeScale(0.5): progress = %v, want 0.25", p)
+	}
+
+	PauseAll()
+	clock.Advance(time.Second)
+
+	if p := a.CurrentPercentageProgress(); !almostEqual(p, 0.25) {
+		t.Errorf("after PauseAll: progress = %v, want 0.25", p)
+	}
+
+	if a.IsPaused() {
+		t.Error("PauseAll should not affect IsPaused")
+	}
+
+	ResumeAll()
+	a.Speed(2)
+	clock.Advance(time.Second / 4)
+
+	if p := a.CurrentPercentageProgress(); !almostEqual(p, 0.5) {
+		t.Errorf("with TimeScale(0.5) and Speed(2): progress = %v, want 0.5", p)
+	}
+}
@@ -1,32 +1,78 @@
 package animations
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/AllenDang/cimgui-go/imgui"
 )
 
-var _ Animation = &TransitionAnimation{}
+var (
+	_ Animation     = &TransitionAnimation{}
+	_ KeyFrameTimer = &TransitionAnimation{}
+	_ MotionReducer = &TransitionAnimation{}
+	_ Validator     = &TransitionAnimation{}
+)
 
 // TransitionAnimation is a smooth transition between two renderers.
 // It may apply to Windows (giu.WindowWidget) as well as to particular widgets/layouts.
 type TransitionAnimation struct {
 	renderers []func(starterFunc StarterFunc)
+	timings   keyFrameTimings
 }
 
 // Transition creates a new TransitionAnimation.
 func Transition(renderers ...func(starter StarterFunc)) *TransitionAnimation {
 	return &TransitionAnimation{
 		renderers: renderers,
+		timings:   make(keyFrameTimings),
 	}
 }
 
+// KeyFrameDuration sets duration of transition to the page kf (see KeyFrameTiming).
+func (t *TransitionAnimation) KeyFrameDuration(kf KeyFrame, d time.Duration) *TransitionAnimation {
+	t.timings.setDuration(kf, d)
+
+	return t
+}
+
+// KeyFrameEasingAlgorithm sets easing algorithm of transition to the page kf (see KeyFrameTiming).
+func (t *TransitionAnimation) KeyFrameEasingAlgorithm(kf KeyFrame, alg EasingAlgorithmType) *TransitionAnimation {
+	t.timings.setEasingAlgorithm(kf, alg)
+
+	return t
+}
+
+// KeyFrameTiming implements KeyFrameTimer.
+func (t *TransitionAnimation) KeyFrameTiming(kf KeyFrame) KeyFrameTiming {
+	return t.timings[kf]
+}
+
+// MotionReduction implements MotionReducer.
+// With reduced motion, transition is a short cross-fade.
+func (t *TransitionAnimation) MotionReduction() MotionReduction {
+	return MotionReductionShort
+}
+
 // KeyFramesCount implements Animation interface.
+// Renderers exceeding maximal number of key frames are ignored (see Validate).
 func (t *TransitionAnimation) KeyFramesCount() KeyFrame {
-	result := len(t.renderers)
-	if result > keyFrameMaxSize {
-		panic("too many renderers for transition animation")
+	return KeyFrame(min(len(t.renderers), keyFrameMaxSize))
+}
+
+// Validate implements Validator.
+func (t *TransitionAnimation) Validate() error {
+	if err := validateKeyFramesCount(len(t.renderers)); err != nil {
+		return err
+	}
+
+	for i, r := range t.renderers {
+		if r == nil {
+			return fmt.Errorf("renderer %d: %w", i, ErrNilKeyFrame)
+		}
 	}
 
-	return KeyFrame(result)
+	return nil
 }
 
 // Reset implements Animation interface.
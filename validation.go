@@ -0,0 +1,153 @@
+package animations
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Errors reported by Validate methods and the error handler (see SetErrorHandler).
+// They are usually wrapped, so use errors.Is to check them.
+var (
+	ErrNoKeyFrames        = errors.New("animation has no key frames")
+	ErrTooManyKeyFrames   = errors.New("too many key frames")
+	ErrNilKeyFrame        = errors.New("key frame is nil")
+	ErrNilWidget          = errors.New("widget is nil")
+	ErrKeyFrameOutOfRange = errors.New("key frame out of range")
+	ErrInvalidFPS         = errors.New("FPS must be positive")
+	ErrInvalidDuration    = errors.New("duration must not be negative")
+	ErrInvalidCycles      = errors.New("number of cycles must not be negative")
+	ErrUnknownEasing      = errors.New("unknown easing algorithm")
+	ErrInvalidState       = errors.New("unexpected type of state")
+)
+
+// Validator could be implemented by an Animation in order to
+// check its configuration (see (*AnimatorWidget).Validate).
+type Validator interface {
+	Validate() error
+}
+
+// errorHandlerSettings holds the function called with errors occurring
+// while building or controlling animations (see errorHandler).
+type errorHandlerSettings struct {
+	f func(err error)
+	m *sync.Mutex
+}
+
+// SetErrorHandler sets a function called when an error occurs while building
+// or controlling an animation (e.g. AnimatorWidget fails to validate or
+// StartKeyFrames receives a key frame out of range).
+// By default LogError is used. nil makes errors ignored.
+func SetErrorHandler(f func(err error)) {
+	errorHandler.m.Lock()
+	defer errorHandler.m.Unlock()
+
+	errorHandler.f = f
+}
+
+// LogError is the default error handler. It logs the error.
+func LogError(err error) {
+	log.Printf("giu-animations: %v", err)
+}
+
+// reportError passes err to the error handler (see SetErrorHandler).
+func reportError(err error) {
+	errorHandler.m.Lock()
+	f := errorHandler.f
+	errorHandler.m.Unlock()
+
+	if f != nil {
+		f(err)
+	}
+}
+
+// Validate checks configuration of the animator and its animation (see Validator).
+// AnimatorWidget validates itself when it is built for the first time: if it is invalid,
+// the error is passed to the error handler (see SetErrorHandler) and the animation is not built.
+func (a *AnimatorWidget) Validate() error {
+	if v, ok := a.animation.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			// key frames of invalid animation could not be checked.
+			return fmt.Errorf("%T: %w", a.animation, err)
+		}
+	}
+
+	var errs []error
+
+	if a.numKeyFrames <= 0 {
+		errs = append(errs, ErrNoKeyFrames)
+	}
+
+	if a.fps <= 0 {
+		errs = append(errs, fmt.Errorf("%w: got %d", ErrInvalidFPS, a.fps))
+	}
+
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"duration", a.duration},
+		{"delay", a.delay},
+		{"cycle delay", a.cycleDelay},
+		{"repeat delay", a.repeatDelay},
+	} {
+		if d.value < 0 {
+			errs = append(errs, fmt.Errorf("%s: %w: got %v", d.name, ErrInvalidDuration, d.value))
+		}
+	}
+
+	if !a.easingAlgorithm.isKnown() {
+		errs = append(errs, fmt.Errorf("%w: %v", ErrUnknownEasing, a.easingAlgorithm))
+	}
+
+	if timer, ok := a.animation.(KeyFrameTimer); ok {
+		for kf := KeyFrame(0); kf < a.numKeyFrames; kf++ {
+			timing := timer.KeyFrameTiming(kf)
+
+			if timing.Duration < 0 {
+				errs = append(errs, fmt.Errorf("key frame %d: %w: got %v", kf, ErrInvalidDuration, timing.Duration))
+			}
+
+			if timing.OverrideEasing && !timing.EasingAlgorithm.isKnown() {
+				errs = append(errs, fmt.Errorf("key frame %d: %w: %v", kf, ErrUnknownEasing, timing.EasingAlgorithm))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateKeyFrames returns an error if any of key frames is out of animator's range.
+func (a *AnimatorWidget) validateKeyFrames(kfs ...KeyFrame) error {
+	for _, kf := range kfs {
+		if kf < 0 || kf >= a.numKeyFrames {
+			return fmt.Errorf("animator %s: %w: %d (animation has %d key frames)", a.id, ErrKeyFrameOutOfRange, kf, a.numKeyFrames)
+		}
+	}
+
+	return nil
+}
+
+// validateCycles returns an error if number of cycles passed to Start* method is negative.
+func (a *AnimatorWidget) validateCycles(count int) error {
+	if count < 0 {
+		return fmt.Errorf("animator %s: %w: got %d", a.id, ErrInvalidCycles, count)
+	}
+
+	return nil
+}
+
+// validateKeyFramesCount is a helper for Validator implementations.
+// It checks if count of key frames is in allowed range.
+func validateKeyFramesCount(count int) error {
+	switch {
+	case count == 0:
+		return ErrNoKeyFrames
+	case count > keyFrameMaxSize:
+		return fmt.Errorf("%w: got %d, at most %d allowed", ErrTooManyKeyFrames, count, keyFrameMaxSize)
+	}
+
+	return nil
+}
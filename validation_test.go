@@ -0,0 +1,188 @@
+package animations
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/AllenDang/giu"
+)
+
+func TestAnimatorWidget_Validate(t *testing.T) {
+	page := func(StarterFunc) {}
+	widget := func(StarterFunc) giu.Widget { return giu.Dummy(1, 1) }
+	red := func() color.RGBA { return color.RGBA{R: 255, A: 255} }
+
+	tests := []struct {
+		name     string
+		animator *AnimatorWidget
+		want     error
+	}{
+		{"valid", Animator(Transition(page, page)), nil},
+		{"no key frames", Animator(Transition()), ErrNoKeyFrames},
+		{"nil renderer", Animator(Transition(page, nil)), ErrNilKeyFrame},
+		{"nil step", Animator(Move(widget, Step(0, 0), nil)), ErrNilKeyFrame},
+		{"valid move", Animator(Move(widget, Step(0, 0), Step(1, 1))), nil},
+		{"nil move widget", Animator(Move(nil, Step(0, 0), Step(1, 1))), ErrNilWidget},
+		{"valid color flow", Animator(ColorFlow(giu.Dummy(1, 1), nil, red, red)), nil},
+		{"nil color flow widget", Animator(ColorFlow(nil, nil, red, red)), ErrNilWidget},
+		{"invalid FPS", Animator(Transition(page, page)).FPS(0), ErrInvalidFPS},
+		{"negative duration", Animator(Transition(page, page)).Duration(-time.Second), ErrInvalidDuration},
+		{"unknown easing", Animator(Transition(page, page)).EasingAlgorithm(EasingAlgMax), ErrUnknownEasing},
+		{
+			"unknown key frame easing",
+			Animator(Transition(page, page).KeyFrameEasingAlgorithm(1, EasingAlgMax)),
+			ErrUnknownEasing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.animator.Validate()
+
+			if tt.want == nil && err != nil {
+				t.Errorf("Validate() = %v, want nil", err)
+			}
+
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetErrorHandler(t *testing.T) {
+	var errs []error
+
+	SetErrorHandler(func(err error) { errs = append(errs, err) })
+	t.Cleanup(func() { SetErrorHandler(LogError) })
+
+	// invalid animator is not built (Transition would panic on BuildNormal).
+	invalid := Animator(Transition()).ID(giu.ID("invalid-" + t.Name()))
+	invalid.Build()
+	invalid.Build()
+
+	if len(errs) != 1 || !errors.Is(errs[0], ErrNoKeyFrames) {
+		t.Fatalf("expected one ErrNoKeyFrames error, got %v", errs)
+	}
+
+	errs = nil
+
+	a, _, _ := newTestAnimator(t, 2)
+	a.StartKeyFrames(0, 5, 0, PlayForward)
+	a.SeekKeyFrame(-1)
+	a.SetKeyFrame(2)
+
+	if a.IsRunning() {
+		t.Error("animation should not start with invalid key frames")
+	}
+
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 errors, got %v", errs)
+	}
+
+	for _, err := range errs {
+		if !errors.Is(err, ErrKeyFrameOutOfRange) {
+			t.Errorf("expected ErrKeyFrameOutOfRange, got %v", err)
+		}
+	}
+}
+
+func TestAnimatorWidget_Revalidate(t *testing.T) {
+	var errs []error
+
+	SetErrorHandler(func(err error) { errs = append(errs, err) })
+	t.Cleanup(func() { SetErrorHandler(LogError) })
+
+	// all builds happen in one frame in tests.
+	SetDuplicateIDHandler(nil)
+	t.Cleanup(func() { SetDuplicateIDHandler(LogDuplicateID) })
+
+	id := giu.ID(t.Name())
+
+	// e.g. data of the animation are still loading.
+	for i := 0; i < 3; i++ {
+		Animator(newTestAnimation(0)).ID(id).Build()
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[0], ErrNoKeyFrames) {
+		t.Fatalf("the same error should be reported once, got %v", errs)
+	}
+
+	anim := newTestAnimation(2)
+	a := Animator(anim).ID(id)
+	a.Build()
+
+	if len(errs) != 1 {
+		t.Errorf("valid animator should not report errors, got %v", errs[1:])
+	}
+
+	anim.m.Lock()
+	if anim.normalCalls != 1 {
+		t.Errorf("animator should be built once it is valid, got %v BuildNormal calls", anim.normalCalls)
+	}
+	anim.m.Unlock()
+
+	s := a.getState()
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.err != nil {
+		t.Errorf("error of the animator should be cleared, got %v", s.err)
+	}
+}
+
+func TestAnimatorWidget_FewerKeyFrames(t *testing.T) {
+	var errs []error
+
+	SetErrorHandler(func(err error) { errs = append(errs, err) })
+	t.Cleanup(func() { SetErrorHandler(LogError) })
+
+	SetDuplicateIDHandler(nil)
+	t.Cleanup(func() { SetDuplicateIDHandler(LogDuplicateID) })
+
+	a, _, clock := newTestAnimator(t, 4)
+	a.Driver(DriverFrame)
+	a.Build()
+	a.SetKeyFrame(2)
+	a.StartKeyFrames(2, 3, 0, PlayForward)
+	clock.Advance(time.Second / 2)
+
+	// e.g. data of the animation got reloaded with fewer pages.
+	anim := newTestAnimation(2)
+	b := Animator(anim).ID(a.id).Clock(clock).Driver(DriverFrame)
+	b.Build()
+
+	if len(errs) != 1 || !errors.Is(errs[0], ErrKeyFrameOutOfRange) {
+		t.Fatalf("expected one ErrKeyFrameOutOfRange error, got %v", errs)
+	}
+
+	if b.IsRunning() {
+		t.Error("animation should be stopped")
+	}
+
+	if kf := b.CurrentKeyFrame(); kf != 1 {
+		t.Errorf("current key frame should be clamped to 1, got %v", kf)
+	}
+
+	b.Build()
+
+	if len(errs) != 1 {
+		t.Errorf("error should be reported once, got %v", errs)
+	}
+
+	anim.m.Lock()
+	defer anim.m.Unlock()
+
+	if anim.normalCalls != 2 || anim.base != 1 {
+		t.Errorf("animation should be built on key frame 1: %v BuildNormal calls, key frame %v", anim.normalCalls, anim.base)
+	}
+}
+
+func TestEase_Unknown(t *testing.T) {
+	if got := Ease(EasingAlgMax, 0.3); got != 0.3 {
+		t.Errorf("unknown easing should be linear: Ease(EasingAlgMax, 0.3) = %v", got)
+	}
+}